@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+/*
+BoardConfiguration is the quest layout of a game. A host may send one in GameConfiguration.Board,
+either a named preset, a full layout, or a preset with some fields overridden.
+Without one the game uses the house layout for its number of active players.
+*/
+type BoardConfiguration struct {
+	Preset             string `json:"preset,omitempty"`
+	NumOfQuests        int    `json:"quests,omitempty"`
+	NumOfBadCharacters int    `json:"evil,omitempty"`
+	PlayersPerLevel    []int  `json:"teamSizes,omitempty"`
+	FailsRequired      []int  `json:"failsRequired,omitempty"`
+	FlushQuests        []int  `json:"flushQuests,omitempty"` // 1 based quest numbers
+	RetriesPerLevel    []int  `json:"retries,omitempty"`
+}
+
+// boardPresets holds the house layouts (house-N, from globalConfigPerNumOfPlayers) and the official Avalon layouts (avalon-N).
+var boardPresets = make(map[string]BoardConfiguration)
+
+func init() {
+	for numOfPlayers := range globalConfigPerNumOfPlayers {
+		boardPresets["house-"+strconv.Itoa(numOfPlayers)] = houseBoard(numOfPlayers)
+	}
+	official := map[int]struct {
+		evil      int
+		teamSizes []int
+		fails     []int
+	}{
+		5:  {2, []int{2, 3, 2, 3, 3}, []int{1, 1, 1, 1, 1}},
+		6:  {2, []int{2, 3, 4, 3, 4}, []int{1, 1, 1, 1, 1}},
+		7:  {3, []int{2, 3, 3, 4, 4}, []int{1, 1, 1, 2, 1}},
+		8:  {3, []int{3, 4, 4, 5, 5}, []int{1, 1, 1, 2, 1}},
+		9:  {3, []int{3, 4, 4, 5, 5}, []int{1, 1, 1, 2, 1}},
+		10: {4, []int{3, 4, 4, 5, 5}, []int{1, 1, 1, 2, 1}},
+	}
+	for numOfPlayers, layout := range official {
+		boardPresets["avalon-"+strconv.Itoa(numOfPlayers)] = BoardConfiguration{Preset: "avalon-" + strconv.Itoa(numOfPlayers),
+			NumOfQuests: 5, NumOfBadCharacters: layout.evil, PlayersPerLevel: layout.teamSizes, FailsRequired: layout.fails,
+			RetriesPerLevel: []int{5, 5, 5, 5, 5}}
+	}
+}
+
+// houseBoard is the layout this server always played: with more than 6 players quest 4 is a flush
+// quest and the one before last needs two fails.
+func houseBoard(numOfPlayers int) BoardConfiguration {
+	config := globalConfigPerNumOfPlayers[numOfPlayers]
+	layout := BoardConfiguration{Preset: "house-" + strconv.Itoa(numOfPlayers), NumOfQuests: config.NumOfQuests,
+		NumOfBadCharacters: config.NumOfBadCharacters, PlayersPerLevel: config.PlayersPerLevel,
+		RetriesPerLevel: config.RetriesPerLevel, FailsRequired: make([]int, config.NumOfQuests)}
+	for level := 1; level <= config.NumOfQuests; level++ {
+		layout.FailsRequired[level-1] = 1
+		if numOfPlayers > 6 && level == 4 {
+			layout.FlushQuests = []int{4}
+		} else if numOfPlayers > 6 && level == config.NumOfQuests-1 {
+			layout.FailsRequired[level-1] = 2
+		}
+	}
+	return layout
+}
+
+/*
+chooseLayout resolves and validates the host's board. Without one, the house layout of the active players
+is used, with the number of evil players of the whole table.
+*/
+func chooseLayout(requested *BoardConfiguration, numOfPlayers int, activePlayers int) (BoardConfiguration, error) {
+	if requested == nil {
+		house, ok := globalConfigPerNumOfPlayers[numOfPlayers]
+		if _, activeOk := globalConfigPerNumOfPlayers[activePlayers]; !ok || !activeOk {
+			return BoardConfiguration{}, newCommandError(ErrInvalidConfig, "no board for "+strconv.Itoa(numOfPlayers)+" players")
+		}
+		layout := houseBoard(activePlayers)
+		layout.NumOfBadCharacters = house.NumOfBadCharacters
+		return layout, nil
+	}
+	layout, err := requested.resolve()
+	if err != nil {
+		return layout, err
+	}
+	return layout, layout.validate(numOfPlayers, activePlayers)
+}
+
+// resolve fills the layout from its preset. Fields that are set override the preset.
+func (layout BoardConfiguration) resolve() (BoardConfiguration, error) {
+	if layout.Preset == "" {
+		return layout, nil
+	}
+	preset, ok := boardPresets[layout.Preset]
+	if !ok {
+		return layout, newCommandError(ErrInvalidConfig, "unknown board preset "+layout.Preset)
+	}
+	if layout.NumOfQuests != 0 {
+		preset.NumOfQuests = layout.NumOfQuests
+	}
+	if layout.NumOfBadCharacters != 0 {
+		preset.NumOfBadCharacters = layout.NumOfBadCharacters
+	}
+	if layout.PlayersPerLevel != nil {
+		preset.PlayersPerLevel = layout.PlayersPerLevel
+	}
+	if layout.FailsRequired != nil {
+		preset.FailsRequired = layout.FailsRequired
+	}
+	if layout.FlushQuests != nil {
+		preset.FlushQuests = layout.FlushQuests
+	}
+	if layout.RetriesPerLevel != nil {
+		preset.RetriesPerLevel = layout.RetriesPerLevel
+	}
+	return preset, nil
+}
+
+// validate checks the layout can be played by numOfPlayers, of which activePlayers go on quests.
+func (layout BoardConfiguration) validate(numOfPlayers int, activePlayers int) error {
+	invalid := func(msg string) error {
+		return newCommandError(ErrInvalidConfig, "board: "+msg)
+	}
+	if layout.NumOfQuests < 1 {
+		return invalid("needs at least one quest")
+	}
+	quests := strconv.Itoa(layout.NumOfQuests)
+	if len(layout.PlayersPerLevel) != layout.NumOfQuests || len(layout.FailsRequired) != layout.NumOfQuests ||
+		len(layout.RetriesPerLevel) != layout.NumOfQuests {
+		return invalid("team sizes, fails required and retries need " + quests + " entries each")
+	}
+	if layout.NumOfBadCharacters < 1 || layout.NumOfBadCharacters >= numOfPlayers {
+		return invalid("needs between 1 and " + strconv.Itoa(numOfPlayers-1) + " evil players")
+	}
+	for i := 0; i < layout.NumOfQuests; i++ {
+		level := strconv.Itoa(i + 1)
+		if layout.PlayersPerLevel[i] < 1 || layout.PlayersPerLevel[i] > activePlayers {
+			return invalid("quest " + level + " needs between 1 and " + strconv.Itoa(activePlayers) + " players")
+		}
+		if layout.FailsRequired[i] < 1 || layout.FailsRequired[i] > layout.PlayersPerLevel[i] {
+			return invalid("quest " + level + " cannot require " + strconv.Itoa(layout.FailsRequired[i]) + " fails")
+		}
+		if layout.RetriesPerLevel[i] < 1 {
+			return invalid("quest " + level + " needs at least one suggestion")
+		}
+	}
+	for _, level := range layout.FlushQuests {
+		if level < 1 || level > layout.NumOfQuests {
+			return invalid("flush quest " + strconv.Itoa(level) + " is not one of the " + quests + " quests")
+		}
+	}
+	return nil
+}
+
+// failsRequired is the number of fails that fail a quest (levels count from 1).
+func (layout BoardConfiguration) failsRequired(level int) int {
+	if level < 1 || level > len(layout.FailsRequired) {
+		return 1
+	}
+	return layout.FailsRequired[level-1]
+}
+
+func (layout BoardConfiguration) typeOfLevel(level int) int {
+	for _, flush := range layout.FlushQuests {
+		if flush == level {
+			return FlushQuest
+		}
+	}
+	if layout.failsRequired(level) > 1 {
+		return TwoFailsRequiredQuest
+	}
+	return RegularQuest
+}
+
+func (layout BoardConfiguration) isGoodVictory(numOfSuccessfulQuests int) bool {
+	return numOfSuccessfulQuests > layout.NumOfQuests/2
+}
+
+func (layout BoardConfiguration) isBadVictory(numOfUnsuccessfulQuests int) bool {
+	return numOfUnsuccessfulQuests > layout.NumOfQuests/2 || (layout.NumOfQuests == 4 && numOfUnsuccessfulQuests == 2)
+}
+
+// boardsPage serves the board presets.
+func boardsPage(res http.ResponseWriter, req *http.Request) {
+	names := make([]string, 0, len(boardPresets))
+	for name := range boardPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	presets := make([]BoardConfiguration, 0, len(names))
+	for _, name := range names {
+		presets = append(presets, boardPresets[name])
+	}
+	res.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(presets)
+}
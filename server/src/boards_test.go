@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_Boards(t *testing.T) {
+	t.Run("Keeps the house layout when no board is sent", should_keep_house_layout)
+	t.Run("Starts a game on an official preset", should_start_game_on_preset)
+	t.Run("Overrides preset fields", should_override_preset_fields)
+	t.Run("Rejects an invalid board", should_reject_invalid_board)
+	t.Run("Fails a quest by the layout's fails required", should_fail_quest_by_fails_required)
+}
+
+func should_keep_house_layout(t *testing.T) {
+	//Act
+	layout, err := chooseLayout(nil, 7, 7)
+
+	//Assert
+	if err != nil {
+		t.Fatal("The house layout should be used, got", err)
+	}
+	if layout.NumOfQuests != 7 || layout.typeOfLevel(4) != FlushQuest || layout.typeOfLevel(6) != TwoFailsRequiredQuest ||
+		layout.typeOfLevel(1) != RegularQuest {
+		t.Error("7 players should play the house layout, got", layout)
+	}
+}
+
+func should_start_game_on_preset(t *testing.T) {
+	//Arrange
+	board, config := seatTestGame(GameConfiguration{Board: &BoardConfiguration{Preset: "avalon-7"}}, Merlin, Percival,
+		LoyalServentOfArthur, LoyalServentOfArthurA, Assassin, Morgana, Mordred)
+
+	//Act
+	err := board.StartGameHandler(config)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Game should start, got", err)
+	}
+	if len(board.quests.results) != 5 || board.quests.results[4].NumOfPlayers != 4 ||
+		board.quests.results[4].Ppp != TwoFailsRequiredQuest {
+		t.Error("The game should follow the avalon-7 layout, got", board.quests.results)
+	}
+	if state := board.GetGameState("alice"); state.Size != 5 || state.Board.Preset != "avalon-7" {
+		t.Error("The game state should show the layout, got", state.Size, state.Board)
+	}
+}
+
+func should_override_preset_fields(t *testing.T) {
+	//Arrange
+	requested := &BoardConfiguration{Preset: "avalon-5", FlushQuests: []int{5}, RetriesPerLevel: []int{3, 3, 3, 3, 3}}
+
+	//Act
+	layout, err := chooseLayout(requested, 5, 5)
+
+	//Assert
+	if err != nil {
+		t.Fatal("The board should be valid, got", err)
+	}
+	if layout.NumOfBadCharacters != 2 || layout.typeOfLevel(5) != FlushQuest || layout.RetriesPerLevel[0] != 3 {
+		t.Error("The overrides should replace the preset fields, got", layout)
+	}
+}
+
+func should_reject_invalid_board(t *testing.T) {
+	for _, requested := range []*BoardConfiguration{
+		{Preset: "avalon-99"},
+		{Preset: "avalon-5", PlayersPerLevel: []int{2, 3, 2}},
+		{Preset: "avalon-5", PlayersPerLevel: []int{2, 3, 2, 3, 6}},
+		{Preset: "avalon-5", FailsRequired: []int{1, 1, 1, 1, 4}},
+		{Preset: "avalon-5", NumOfBadCharacters: 5},
+		{Preset: "avalon-5", FlushQuests: []int{6}},
+		{NumOfQuests: 1, NumOfBadCharacters: 1, PlayersPerLevel: []int{2}, FailsRequired: []int{1}, RetriesPerLevel: []int{0}},
+	} {
+		//Act
+		_, err := chooseLayout(requested, 5, 5)
+
+		//Assert
+		if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidConfig {
+			t.Error("Expected invalid_config for", *requested, "got", err)
+		}
+	}
+}
+
+func should_fail_quest_by_fails_required(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{Board: &BoardConfiguration{Preset: "avalon-5", FailsRequired: []int{1, 1, 1, 3, 1}}},
+		classicRoles...)
+	merlin, assassin := playerOf(board, Merlin), playerOf(board, Assassin)
+	playQuest(t, board, questTeam(board, merlin, playerOf(board, Percival)))
+	for i := 0; i < 2; i++ {
+		playQuest(t, board, questTeam(board, assassin, merlin, playerOf(board, Percival)), assassin)
+	}
+
+	//Act
+	twoFails := board.CalculateQuestResult([]int{VoteFail, VoteFail, VoteSuccess})
+	threeFails := board.CalculateQuestResult([]int{VoteFail, VoteFail, VoteFail})
+
+	//Assert
+	if twoFails != JorneySuccess || threeFails != JorneyFail {
+		t.Error("A quest needing 3 fails should fail only on 3, got", twoFails, threeFails)
+	}
+	if !board.layout.isBadVictory(3) || board.layout.isBadVictory(2) || !board.layout.isGoodVictory(3) {
+		t.Error("5 quests should be won by 3")
+	}
+}
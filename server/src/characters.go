@@ -1,6 +1,211 @@
 package main
 
-type Character interface {
-	UpdateDescription()
+/*
+CharacterRules is everything the game needs to know about one role. The handlers ask the
+registry (characterRules) instead of checking role names.
+Empty fields are filled from Loyalty when the role is registered.
+*/
+type CharacterRules struct {
+	Name    string
+	Loyalty string // GOOD, BAD or NEUTRAL
+	Side    string // the side the role fills in a game configuration. defaults to Loyalty
 
+	// Cards the role may play on a regular and on a flush quest.
+	QuestCards []string
+	FlushCards []string
+	// QuestCardsHook overrides the cards above when it returns a non nil slice.
+	QuestCardsHook func(board *BoardGame, quest QuestContext) []string
+	// OnQuestCard runs after the role played a card.
+	OnQuestCard func(board *BoardGame, vote int)
+	// QuestResultHook may change the result of a quest the role was on.
+	QuestResultHook func(board *BoardGame, votes []int, result int) int
+	// WinCheck may end the game after a quest by naming the winners (GOOD or BAD). "" plays on.
+	WinCheck func(board *BoardGame) string
+	// AfterQuest runs once the result of a quest is counted, whether or not the role was on it.
+	AfterQuest func(board *BoardGame, player PlayerName)
+
+	// LadyAnswers are the loyalties the role may answer to the Lady Of The Lake.
+	LadyAnswers []string
+	// InspectedAnswer is what the other loyalty checks (the Cleric, plot cards, messengers) find, as the role
+	// does not choose there. Defaults to the first of LadyAnswers.
+	InspectedAnswer string
+	// ShownToViviana uncovers the role to Viviana after it suggested an accepted quest.
+	ShownToViviana func(board *BoardGame, suggester PlayerName, character string)
+
+	// Night fills what the role learns when the game starts.
+	Night func(n *night)
+	// The role also sees the players of SeesSpecifically roles, and the colour of SeesAsColor roles.
+	SeesSpecifically []string
+	SeesAsColor      []string
+	// UnawareOfNirlem is set on goods that do not see Nirlem, UnawareOfEvil on bads that do not see their team.
+	UnawareOfNirlem bool
+	UnawareOfEvil   bool
+	// HiddenFromEvil roles are not seen by the bad team, SeenByEvil roles are seen even though they are not bad.
+	HiddenFromEvil bool
+	SeenByEvil     bool
+
+	// Murders the role tries after the goods or the bads won the quests.
+	Murders func(board *BoardGame, goodsWon bool) []Murder
+	// AssassinTarget roles are on the assassin's list after the goods won.
+	AssassinTarget bool
+	// Assassinated runs when the Assassin names the role's player. Returning true gives the Assassin another try.
+	Assassinated func(board *BoardGame, player PlayerName) bool
+}
+
+// QuestContext is what the quest card rules may look at.
+type QuestContext struct {
+	Members      map[string]bool // characters on the quest
+	Flags        map[int]bool
+	Current      int
+	NumOfPlayers int
+	Flush        bool
+}
+
+// The registry keeps the order roles were registered in. Murders and assassin targets are collected in that order.
+var characterRegistry []*CharacterRules
+var characterIndex = make(map[string]*CharacterRules)
+
+// characterRules returns the rules of a role, or nil for an unknown role.
+func characterRules(character string) *CharacterRules {
+	return characterIndex[character]
+}
+
+func registerCharacter(rules CharacterRules) {
+	if rules.Side == "" {
+		rules.Side = rules.Loyalty
+	}
+	if rules.QuestCards == nil {
+		rules.QuestCards = []string{SUCCESS}
+		if rules.Loyalty == BAD {
+			rules.QuestCards = []string{SUCCESS, FAIL}
+		}
+	}
+	if rules.FlushCards == nil {
+		rules.FlushCards = []string{SUCCESS}
+		if rules.Loyalty == BAD {
+			rules.FlushCards = []string{FAIL}
+		}
+	}
+	if rules.LadyAnswers == nil {
+		rules.LadyAnswers = []string{rules.Loyalty}
+	}
+	if rules.InspectedAnswer == "" {
+		rules.InspectedAnswer = rules.LadyAnswers[0]
+	}
+	if rules.ShownToViviana == nil {
+		rules.ShownToViviana = vivianaSeesGood
+		if rules.Loyalty == BAD {
+			rules.ShownToViviana = vivianaSeesBad
+		}
+	}
+
+	characterRegistry = append(characterRegistry, &rules)
+	characterIndex[rules.Name] = &rules
+	switch rules.Loyalty {
+	case GOOD:
+		goodCharacters[rules.Name] = true
+	case BAD:
+		badCharacters[rules.Name] = true
+	default:
+		neutralCharacters[rules.Name] = true
+	}
+}
+
+func init() {
+	for _, rules := range []CharacterRules{
+		// Goods
+		{Name: Pellinore, Loyalty: GOOD, Murders: pellinoreMurders},
+		{Name: Cordana, Loyalty: GOOD, Murders: cordanaMurders},
+		{Name: Percival, Loyalty: GOOD, Night: percivalNight, Murders: percivalMurders},
+		{Name: KingArthur, Loyalty: GOOD, QuestCards: []string{FAIL}, FlushCards: []string{FAIL}, Murders: kingArthurMurders},
+		{Name: MerlinApprentice, Loyalty: GOOD, Night: merlinApprenticeNight, AssassinTarget: true},
+		{Name: Merlin, Loyalty: GOOD, Night: merlinNight, AssassinTarget: true},
+		{Name: Viviana, Loyalty: GOOD, AssassinTarget: true},
+		{Name: Nirlem, Loyalty: GOOD, UnawareOfNirlem: true, AssassinTarget: true},
+		{Name: Seer, Loyalty: GOOD},
+		{Name: Titanya, Loyalty: GOOD, QuestCardsHook: titanyaCards, OnQuestCard: titanyaPlayed},
+		{Name: Galahad, Loyalty: GOOD},
+		{Name: Nimue, Loyalty: GOOD, Night: seesRoles(Galahad, Merlin), QuestCardsHook: nimueCards},
+		{Name: SirKay, Loyalty: GOOD, ShownToViviana: vivianaSeesBad},
+		{Name: GoodAngel, Loyalty: GOOD, QuestCards: []string{REVERSAL, SUCCESS}},
+		{Name: Tristan, Loyalty: GOOD, Night: seesRoles(Iseult)},
+		{Name: Iseult, Loyalty: GOOD, Night: seesRoles(Tristan)},
+		{Name: PrinceClaudin, Loyalty: GOOD, Night: seesRoles(KingClaudin)},
+		{Name: SirRobin, Loyalty: GOOD},
+		{Name: Lot, Loyalty: GOOD, QuestCards: []string{SUCCESS}, FlushCards: []string{SUCCESS}, LadyAnswers: []string{BAD},
+			ShownToViviana: vivianaSeesBadAndRole, Night: lotNight, UnawareOfNirlem: true},
+		{Name: TheCoward, Loyalty: GOOD},
+		{Name: LancelotGood, Loyalty: GOOD, UnawareOfNirlem: true},
+		{Name: Guinevere, Loyalty: GOOD, Night: guinevereNight},
+		{Name: Galaad, Loyalty: GOOD},
+		{Name: Raven, Loyalty: GOOD, LadyAnswers: []string{BAD}},
+		{Name: Balain, Loyalty: GOOD, Night: seesRoles(Balin), UnawareOfNirlem: true},
+		{Name: SirGawain, Loyalty: GOOD},
+		{Name: Jarvan, Loyalty: GOOD},
+		{Name: Stray, Loyalty: GOOD, ShownToViviana: vivianaSeesRole},
+		{Name: Ector, Loyalty: GOOD},
+		{Name: Elaine, Loyalty: GOOD, QuestCardsHook: elaineCards, OnQuestCard: elainePlayed},
+		{Name: Blanchefleur, Loyalty: GOOD},
+		{Name: TomThumb, Loyalty: GOOD},
+		{Name: Gornemant, Loyalty: GOOD, Night: gornemantNight},
+		{Name: Dagonet, Loyalty: GOOD, Night: seesRoles(Oberon)},
+		{Name: Meliagant, Loyalty: GOOD, QuestCards: []string{FAIL, SUCCESS}, FlushCards: []string{FAIL}, LadyAnswers: []string{BAD},
+			Night: meliagantNight, UnawareOfNirlem: true, SeenByEvil: true},
+		{Name: Bors, Loyalty: GOOD},
+		{Name: UtherPendragon, Loyalty: GOOD, QuestCards: []string{EMPTY}, FlushCards: []string{EMPTY}},
+		{Name: LoyalServentOfArthur, Loyalty: GOOD},
+		{Name: LoyalServentOfArthurA, Loyalty: GOOD},
+		{Name: LoyalServentOfArthurB, Loyalty: GOOD},
+		{Name: LoyalServentOfArthurC, Loyalty: GOOD},
+		{Name: LoyalServentOfArthurD, Loyalty: GOOD},
+		{Name: Cleric, Loyalty: GOOD, Night: clericNight},
+		{Name: UntrustworthyServant, Loyalty: GOOD, SeenByEvil: true, Assassinated: untrustworthyServantAssassinated},
+		{Name: Troublemaker, Loyalty: GOOD, LadyAnswers: []string{BAD}, ShownToViviana: vivianaSeesBad},
+		{Name: GoodSorcerer, Loyalty: GOOD, QuestCards: []string{MAGIC, SUCCESS}},
+		{Name: GoodRogue, Loyalty: GOOD, QuestCardsHook: rogueCards(GoodRogue), OnQuestCard: roguePlayed(GoodRogue),
+			AfterQuest: rogueAfterQuest},
+		{Name: SeniorMessenger, Loyalty: GOOD, Night: messengerNight},
+		{Name: JuniorMessenger, Loyalty: GOOD, Night: messengerNight},
+
+		// Bads
+		{Name: Morgana, Loyalty: BAD, Night: seesRoles(Gawain)},
+		{Name: Assassin, Loyalty: BAD, Murders: assassinMurders},
+		{Name: Mordred, Loyalty: BAD, ShownToViviana: vivianaSeesGood},
+		{Name: Oberon, Loyalty: BAD, ShownToViviana: vivianaSeesRole, UnawareOfEvil: true, HiddenFromEvil: true},
+		{Name: BadAngel, Loyalty: BAD, QuestCards: []string{REVERSAL, SUCCESS, FAIL}},
+		{Name: KingClaudin, Loyalty: BAD, Night: seesRoles(PrinceClaudin)},
+		{Name: Polygraph, Loyalty: BAD, QuestCards: []string{FAIL}, FlushCards: []string{FAIL}},
+		{Name: Accolon, Loyalty: BAD, UnawareOfEvil: true, HiddenFromEvil: true},
+		{Name: LancelotBad, Loyalty: BAD, QuestCards: []string{FAIL}, FlushCards: []string{FAIL}, UnawareOfEvil: true},
+		{Name: QueenMab, Loyalty: BAD, LadyAnswers: []string{BAD, GOOD}},
+		{Name: Balin, Loyalty: BAD, QuestCards: []string{FAIL}, FlushCards: []string{FAIL}, Night: seesRoles(Balain), UnawareOfEvil: true},
+		{Name: Maeve, Loyalty: BAD},
+		{Name: Agravain, Loyalty: BAD, QuestCards: []string{SUCCESS}, FlushCards: []string{SUCCESS}, UnawareOfEvil: true, HiddenFromEvil: true},
+		{Name: Nerzhul, Loyalty: BAD, Night: seesRoles(Oberon)},
+		{Name: Mora, Loyalty: BAD, FlushCards: []string{FAIL, SUCCESS}},
+		{Name: Melwas, Loyalty: BAD},
+		{Name: Claudas, Loyalty: BAD, Night: claudasNight},
+		{Name: MinionOfMordred, Loyalty: BAD},
+		{Name: MinionOfMordredA, Loyalty: BAD},
+		{Name: MinionOfMordredB, Loyalty: BAD},
+		{Name: Lunatic, Loyalty: BAD, QuestCards: []string{FAIL}, FlushCards: []string{FAIL}},
+		{Name: Brute, Loyalty: BAD, QuestCardsHook: bruteCards},
+		{Name: Revealer, Loyalty: BAD, AfterQuest: revealerAfterQuest},
+		{Name: Trickster, Loyalty: BAD, LadyAnswers: []string{BAD, GOOD}, InspectedAnswer: GOOD},
+		{Name: EvilSorcerer, Loyalty: BAD, QuestCards: []string{MAGIC, SUCCESS, FAIL}},
+		{Name: EvilRogue, Loyalty: BAD, QuestCardsHook: rogueCards(EvilRogue), OnQuestCard: roguePlayed(EvilRogue),
+			AfterQuest: rogueAfterQuest},
+		{Name: EvilMessenger, Loyalty: BAD, Night: messengerNight},
+
+		// Neutrals
+		{Name: Ginerva, Loyalty: NEUTRAL, Side: BAD, QuestCards: []string{SUCCESS, FAIL}, FlushCards: []string{FAIL},
+			LadyAnswers: []string{BAD}, ShownToViviana: vivianaSeesBad},
+		{Name: Puck, Loyalty: NEUTRAL, Side: GOOD, QuestCards: []string{SUCCESS, FAIL}, LadyAnswers: []string{GOOD}},
+		{Name: Gawain, Loyalty: NEUTRAL, Side: BAD, QuestCards: []string{FAIL, SUCCESS}, FlushCards: []string{FAIL, SUCCESS},
+			LadyAnswers: []string{BAD}, ShownToViviana: vivianaSeesBadAndRole, Night: gawainNight},
+		{Name: TheQuestingBeast, Loyalty: NEUTRAL, Side: BAD, QuestCards: []string{SUCCESS, BEAST}, QuestCardsHook: beastCards,
+			OnQuestCard: beastPlayed, Night: seesRoles(Pellinore)},
+	} {
+		registerCharacter(rules)
+	}
 }
@@ -7,6 +7,9 @@ const (
 	BEAST = "Beast"
 	EMPTY = "Empty"
 	AVALON_POWER = "Avalon Power"
+	MAGIC = "Magic"
+	ROGUE_SUCCESS = "Rogue Success"
+	ROGUE_FAIL = "Rogue Fail"
 )
 
 const (
@@ -16,24 +19,24 @@ const (
 )
 
 type CharacterDescription struct {
-	Loyalty string `json:"loyalty,omitempty"`
-	CanSeeAsColor []string `json:"canSeeAsColor,omitempty"`
-	CanSeeSpecifically []string `json:"canSeeSpecifically,omitempty"`
-	SeenAsColorBy []string `json:"seenAsColorBy,omitempty"`
-	SeenSpecificallyBy []string `json:"seenSpecificallyBy,omitempty"`
+	Loyalty string `json:"loyalty,omitempty" yaml:"loyalty,omitempty"`
+	CanSeeAsColor []string `json:"canSeeAsColor,omitempty" yaml:"canSeeAsColor,omitempty"`
+	CanSeeSpecifically []string `json:"canSeeSpecifically,omitempty" yaml:"canSeeSpecifically,omitempty"`
+	SeenAsColorBy []string `json:"seenAsColorBy,omitempty" yaml:"seenAsColorBy,omitempty"`
+	SeenSpecificallyBy []string `json:"seenSpecificallyBy,omitempty" yaml:"seenSpecificallyBy,omitempty"`
 
-	CanVote []string `json:"canVote,omitempty"`
-	CanVoteOnFlush []string `json:"canVoteOnFlush,omitempty"`
-	MurderedBy []string `json:"murderedBy,omitempty"`
-	Murder []string `json:"murder,omitempty"`
-	SpecialRole string `json:"specialRole,omitempty"`
+	CanVote []string `json:"canVote,omitempty" yaml:"canVote,omitempty"`
+	CanVoteOnFlush []string `json:"canVoteOnFlush,omitempty" yaml:"canVoteOnFlush,omitempty"`
+	MurderedBy []string `json:"murderedBy,omitempty" yaml:"murderedBy,omitempty"`
+	Murder []string `json:"murder,omitempty" yaml:"murder,omitempty"`
+	SpecialRole string `json:"specialRole,omitempty" yaml:"specialRole,omitempty"`
 }
 
 var CharactersDescriptionMap = map[string]CharacterDescription{
 	Merlin: {GOOD,
 		[]string{Morgana, Assassin, BadAngel, KingClaudin, Polygraph,
 			LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, SirKay, Melwas, Claudas,
-			MinionOfMordred, Meliagant},
+			MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Meliagant},
 		[]string{Oberon, Nirlem, Lot, Stray, Gawain, Ector},
 		[]string{Gawain, Percival},
 		[]string{Nimue},
@@ -44,14 +47,14 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		"He has a lot of information but he has to be careful not to be killed at the end of the game",
 	},
 	Percival: {GOOD,
-		[]string{Merlin, Morgana, Viviana},
+		[]string{Merlin, Morgana, Viviana, GoodSorcerer, EvilSorcerer},
 		[]string{Nirlem, Ector},
 		[]string{MerlinApprentice, Gawain},
 		[]string{},
 		[]string{SUCCESS},
 		[]string{SUCCESS},
 		[]string{""},
-		[]string{Morgana, Assassin, BadAngel, KingClaudin, Polygraph, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Oberon, Lot, Mordred, Accolon, Agravain, Meliagant},
+		[]string{Morgana, Assassin, BadAngel, KingClaudin, Polygraph, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Oberon, Lot, Mordred, Accolon, Agravain, Meliagant},
 		"He has a lot of information but he has to be careful not to be killed at the end of the game",
 	},
 	Titanya: {GOOD,
@@ -117,7 +120,7 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		[]string{"fake Fail"},
 		[]string{"fake Fail"},
 		[]string{""},
-		[]string{Morgana, Assassin, BadAngel, KingClaudin, Polygraph, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Oberon, Lot, Mordred, Accolon, Agravain, Meliagant},
+		[]string{Morgana, Assassin, BadAngel, KingClaudin, Polygraph, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Oberon, Lot, Mordred, Accolon, Agravain, Meliagant},
 		"can put only fake fail we all will see the fail but the game know its not a real fail, if bad guys won he can try to kill them all if he correct good guys win",
 	},
 	Puck: {NEUTRAL,
@@ -209,7 +212,7 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		"can win only if 1. The beast didn’t put beast card or 2. If he and the beast was on the same quest and the beast used beast card in it or 3. If he kill the beast in the end of the game",
 	},
 	Lot: {BAD,
-		[]string{Morgana, Assassin, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Mordred, Meliagant},
+		[]string{Morgana, Assassin, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Mordred, Meliagant},
 		[]string{Ector, Polygraph, Stray},
 		[]string{Gawain},
 		[]string{Merlin, Viviana, Meliagant},
@@ -333,7 +336,7 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		[]string{"Unknown"},
 		[]string{"Unknown", Ector},
 		[]string{"Unknown"},
-		[]string{Merlin, Viviana, Morgana, Assassin, BadAngel, KingClaudin, Polygraph, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lot, Mordred, Agravain, Meliagant},
+		[]string{Merlin, Viviana, Morgana, Assassin, BadAngel, KingClaudin, Polygraph, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Lot, Mordred, Agravain, Meliagant},
 		[]string{"Unknown"},
 		[]string{"Unknown"},
 		[]string{"Unknown"},
@@ -344,7 +347,7 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		[]string{},
 		[]string{Nirlem},
 		[]string{},
-		[]string{Merlin, Percival, GoodAngel, Titanya, Nimue, Galahad, SirKay, Seer, KingArthur, Puck, Viviana, Tristan, Iseult, PrinceClaudin, Nirlem, SirRobin, Pellinore, Lot, Cordana, TheCoward, LoyalServentOfArthur, MerlinApprentice, Guinevere, LancelotGood, Raven, Balain, SirGawain, Stray, Elaine, Blanchefleur, TomThumb, Gornemant, Dagonet, Agravain, Bors, UtherPendragon, Morgana, Assassin, Mordred, Oberon, BadAngel, KingClaudin, Ginerva, Polygraph, TheQuestingBeast, Accolon, Gawain, LancelotBad, QueenMab, Balin, Maeve, Meliagant, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Jarvan},
+		[]string{Merlin, Percival, GoodAngel, Titanya, Nimue, Galahad, SirKay, Seer, KingArthur, Puck, Viviana, Tristan, Iseult, PrinceClaudin, Nirlem, SirRobin, Pellinore, Lot, Cordana, TheCoward, LoyalServentOfArthur, MerlinApprentice, Guinevere, LancelotGood, Raven, Balain, SirGawain, Stray, Elaine, Blanchefleur, TomThumb, Gornemant, Dagonet, Agravain, Bors, UtherPendragon, Morgana, Assassin, Mordred, Oberon, BadAngel, KingClaudin, Ginerva, Polygraph, TheQuestingBeast, Accolon, Gawain, LancelotBad, QueenMab, Balin, Maeve, Meliagant, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Jarvan},
 		[]string{},
 		[]string{},
 		[]string{},
@@ -408,8 +411,8 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	},
 	Meliagant: {BAD,
 		[]string{},
-		[]string{Ector, Lot, Morgana, Assassin, Mordred, Oberon, BadAngel, KingClaudin, Polygraph, Accolon, LancelotBad, QueenMab, Balin, Maeve, Agravain, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Stray},
-		[]string{Merlin, Viviana, Lot, Morgana, Assassin, Mordred, BadAngel, KingClaudin, Polygraph, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Gawain},
+		[]string{Ector, Lot, Morgana, Assassin, Mordred, Oberon, BadAngel, KingClaudin, Polygraph, Accolon, LancelotBad, QueenMab, Balin, Maeve, Agravain, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Stray},
+		[]string{Merlin, Viviana, Lot, Morgana, Assassin, Mordred, BadAngel, KingClaudin, Polygraph, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Gawain},
 		[]string{},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -451,9 +454,9 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		"can reveal himself if 1. Someone used Excaliber on him and then his vote stay success or 2. If he isn’t in the quest and he want to take excaliber and use it on someone and then if he correct its ok but if he use it on success he cant join to any more quests for the res of the game or 3. If he didn’t reveal himself until the last quest he can reveal himself and use the excaliber on as many player he want. If he revealed himself he can protect one player from been murdered",
 	},
 	Morgana: {BAD,
-		[]string{Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+		[]string{Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Gawain, Polygraph, Ector, Stray},
-		[]string{Assassin, Mordred, BadAngel, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lot, Gawain, Percival, Merlin, Viviana},
+		[]string{Assassin, Mordred, BadAngel, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Lot, Gawain, Percival, Merlin, Viviana},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -462,9 +465,9 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		"can try to confuse Percival",
 	},
 	Assassin: {BAD,
-		[]string{Morgana, Mordred, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+		[]string{Morgana, Mordred, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector, Polygraph, Stray},
-		[]string{Morgana, Mordred, BadAngel, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lot, Gawain, Merlin, Viviana, MerlinApprentice},
+		[]string{Morgana, Mordred, BadAngel, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Lot, Gawain, Merlin, Viviana, MerlinApprentice},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -473,9 +476,9 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		"can kill in the end of the game and win the game for bad guys",
 	},
 	Mordred: {BAD,
-		[]string{Assassin, Morgana, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+		[]string{Assassin, Morgana, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Polygraph, Ector, Stray},
-		[]string{Assassin, Morgana, BadAngel, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lot, Gawain},
+		[]string{Assassin, Morgana, BadAngel, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Lot, Gawain},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -495,9 +498,9 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		"doesn’t know the bads and they don’t know him",
 	},
 	BadAngel: {BAD,
-		[]string{Morgana, Mordred, Assassin, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+		[]string{Morgana, Mordred, Assassin, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Polygraph, Ector, Stray},
-		[]string{Gawain, Merlin, Viviana, Morgana, Mordred, Assassin, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lot},
+		[]string{Gawain, Merlin, Viviana, Morgana, Mordred, Assassin, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Lot},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL, REVERSAL},
 		[]string{FAIL},
@@ -506,9 +509,9 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		"can put the reversal card and reverse the outcome of the quest",
 	},
 	KingClaudin: {BAD,
-		[]string{Morgana, Mordred, Assassin, BadAngel, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+		[]string{Morgana, Mordred, Assassin, BadAngel, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector, Polygraph, PrinceClaudin, Stray},
-		[]string{Gawain, Merlin, Viviana, Morgana, Mordred, Assassin, BadAngel, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lot},
+		[]string{Gawain, Merlin, Viviana, Morgana, Mordred, Assassin, BadAngel, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Lot},
 		[]string{PrinceClaudin, Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -528,10 +531,10 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		"to win she need to get to the last quest on the bored and that the last quest will be a fail",
 	},
 	Polygraph: {BAD,
-		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, QueenMab, Balin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector, Stray},
 		[]string{Gawain, Merlin, Viviana},
-		[]string{Lot, Morgana, Assassin, Mordred, BadAngel, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Meliagant},
+		[]string{Lot, Morgana, Assassin, Mordred, BadAngel, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Meliagant},
 		[]string{FAIL},
 		[]string{FAIL},
 		[]string{Percival, KingArthur},
@@ -562,7 +565,7 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	},
 	Gawain: {NEUTRAL,
 		[]string{Merlin, Morgana, Percival, Assassin, Mordred, Oberon, BadAngel, KingClaudin, Polygraph,
-			LancelotBad, QueenMab, Balin, Viviana, Nirlem, Maeve, Meliagant, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+			LancelotBad, QueenMab, Balin, Viviana, Nirlem, Maeve, Meliagant, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector},
 		[]string{},
 		[]string{Merlin, Viviana, Morgana},
@@ -576,7 +579,7 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		[]string{},
 		[]string{Ector},
 		[]string{Guinevere, Morgana, Merlin, Viviana, Assassin, Mordred, BadAngel, KingClaudin, Polygraph,
-			QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Gawain, Lot},
+			QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, Gawain, Lot},
 		[]string{Meliagant},
 		[]string{FAIL},
 		[]string{FAIL},
@@ -586,10 +589,10 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	},
 	QueenMab: {BAD,
 		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin, Maeve, Nerzhul,
-			Mora, Melwas, Claudas, MinionOfMordred},
+			Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Polygraph, Ector, Stray},
 		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin, Maeve,
-			Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+			Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -600,7 +603,7 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	Balin: {BAD,
 		[]string{},
 		[]string{Balain, Ector},
-		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, QueenMab},
+		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger, QueenMab},
 		[]string{Meliagant, Balain},
 		[]string{FAIL},
 		[]string{FAIL},
@@ -610,10 +613,10 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	},
 	Maeve: {BAD,
 		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin, QueenMab,
-			Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+			Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector, Polygraph, Stray},
 		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
-			QueenMab, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred},
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -634,10 +637,10 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	},
 	Nerzhul: {BAD,
 		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin, QueenMab,
-			Maeve, Mora, Melwas, Claudas, MinionOfMordred},
+			Maeve, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector, Oberon, Polygraph, Stray},
 		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
-			QueenMab, Maeve, Mora, Melwas, Claudas, MinionOfMordred},
+			QueenMab, Maeve, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -647,10 +650,10 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	},
 	Mora: {BAD,
 		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin, QueenMab,
-			Nerzhul, Maeve, Melwas, Claudas, MinionOfMordred},
+			Nerzhul, Maeve, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector, Polygraph, Stray},
 		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
-			QueenMab, Nerzhul, Maeve, Melwas, Claudas, MinionOfMordred},
+			QueenMab, Nerzhul, Maeve, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{SUCCESS, FAIL},
@@ -660,10 +663,10 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	},
 	Melwas: {BAD,
 		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin, QueenMab,
-			Nerzhul, Mora, Maeve, Claudas, MinionOfMordred},
+			Nerzhul, Mora, Maeve, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector, Polygraph, Stray},
 		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
-			QueenMab, Nerzhul, Mora, Maeve, Claudas, MinionOfMordred},
+			QueenMab, Nerzhul, Mora, Maeve, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -673,10 +676,10 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	},
 	Claudas: {BAD,
 		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin, QueenMab,
-			Nerzhul, Mora, Melwas, Maeve, MinionOfMordred},
+			Nerzhul, Mora, Melwas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector, Polygraph, Oberon, SirKay, Stray},
 		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
-			QueenMab, Nerzhul, Mora, Melwas, Maeve, MinionOfMordred},
+			QueenMab, Nerzhul, Mora, Melwas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -686,10 +689,10 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 	},
 	MinionOfMordred: {BAD,
 		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin,
-			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve},
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Ector, Polygraph, Stray},
 		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
-			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve},
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
 		[]string{Meliagant},
 		[]string{SUCCESS, FAIL},
 		[]string{FAIL},
@@ -697,4 +700,174 @@ var CharactersDescriptionMap = map[string]CharacterDescription{
 		[]string{},
 		"Minion-Of-Mordred",
 	},
+	Lunatic: {BAD,
+		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
+		[]string{Ector, Polygraph, Stray},
+		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
+		[]string{Meliagant},
+		[]string{FAIL},
+		[]string{FAIL},
+		[]string{Percival, KingArthur},
+		[]string{},
+		"must fail every quest he goes on",
+	},
+	Brute: {BAD,
+		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
+		[]string{Ector, Polygraph, Stray},
+		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
+		[]string{Meliagant},
+		[]string{SUCCESS, FAIL},
+		[]string{FAIL},
+		[]string{Percival, KingArthur},
+		[]string{},
+		"may fail only the first three quests, after that he must succeed",
+	},
+	Revealer: {BAD,
+		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
+		[]string{Ector, Polygraph, Stray},
+		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
+		[]string{Meliagant},
+		[]string{SUCCESS, FAIL},
+		[]string{FAIL},
+		[]string{Percival, KingArthur},
+		[]string{},
+		"is revealed to everyone after the second failed quest",
+	},
+	Trickster: {BAD,
+		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, EvilSorcerer},
+		[]string{Ector, Polygraph, Stray},
+		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, EvilSorcerer},
+		[]string{Meliagant},
+		[]string{SUCCESS, FAIL},
+		[]string{FAIL},
+		[]string{Percival, KingArthur},
+		[]string{},
+		"can lie to Lady of the lake",
+	},
+	Cleric: {GOOD,
+		[]string{},
+		[]string{Nirlem, Ector},
+		[]string{},
+		[]string{},
+		[]string{SUCCESS},
+		[]string{SUCCESS},
+		[]string{},
+		[]string{},
+		"knows the loyalty of the first quest leader",
+	},
+	UntrustworthyServant: {GOOD,
+		[]string{},
+		[]string{Nirlem, Ector},
+		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, QueenMab, Maeve, Nerzhul, Mora, Melwas, Claudas, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue, EvilMessenger},
+		[]string{},
+		[]string{SUCCESS},
+		[]string{SUCCESS},
+		[]string{},
+		[]string{},
+		"the bads see him as bad. if the Assassin names him he becomes bad and the Assassin tries again",
+	},
+	Troublemaker: {GOOD,
+		[]string{},
+		[]string{Nirlem, Ector},
+		[]string{},
+		[]string{},
+		[]string{SUCCESS},
+		[]string{SUCCESS},
+		[]string{},
+		[]string{},
+		"must lie about his loyalty to Lady of the lake",
+	},
+	GoodSorcerer: {GOOD,
+		[]string{},
+		[]string{Nirlem, Ector},
+		[]string{Percival},
+		[]string{},
+		[]string{SUCCESS, MAGIC},
+		[]string{SUCCESS},
+		[]string{},
+		[]string{},
+		"can put the magic card and flip the outcome of the quest",
+	},
+	EvilSorcerer: {BAD,
+		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilRogue, EvilMessenger},
+		[]string{Ector, Polygraph, Stray},
+		[]string{Lot, Merlin, Percival, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilRogue, EvilMessenger},
+		[]string{Meliagant},
+		[]string{SUCCESS, FAIL, MAGIC},
+		[]string{FAIL},
+		[]string{Percival, KingArthur},
+		[]string{},
+		"can put the magic card and flip the outcome of the quest",
+	},
+	GoodRogue: {GOOD,
+		[]string{},
+		[]string{Nirlem, Ector},
+		[]string{},
+		[]string{},
+		[]string{SUCCESS, ROGUE_SUCCESS},
+		[]string{SUCCESS, ROGUE_SUCCESS},
+		[]string{},
+		[]string{},
+		"wins on his own by playing his Rogue Success card on a quest that succeeds",
+	},
+	EvilRogue: {BAD,
+		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilMessenger},
+		[]string{Ector, Polygraph, Stray},
+		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilMessenger},
+		[]string{Meliagant},
+		[]string{SUCCESS, FAIL, ROGUE_FAIL},
+		[]string{FAIL, ROGUE_FAIL},
+		[]string{Percival, KingArthur},
+		[]string{},
+		"wins on his own by playing his Rogue Fail card on a quest that fails",
+	},
+	SeniorMessenger: {GOOD,
+		[]string{JuniorMessenger, EvilMessenger},
+		[]string{Nirlem, Ector},
+		[]string{JuniorMessenger, EvilMessenger},
+		[]string{},
+		[]string{SUCCESS},
+		[]string{SUCCESS},
+		[]string{},
+		[]string{},
+		"knows the other messengers and has two tokens to learn the loyalty of other players",
+	},
+	JuniorMessenger: {GOOD,
+		[]string{SeniorMessenger, EvilMessenger},
+		[]string{Nirlem, Ector},
+		[]string{SeniorMessenger, EvilMessenger},
+		[]string{},
+		[]string{SUCCESS},
+		[]string{SUCCESS},
+		[]string{},
+		[]string{},
+		"knows the other messengers and has one token to learn the loyalty of another player",
+	},
+	EvilMessenger: {BAD,
+		[]string{Morgana, Assassin, Mordred, BadAngel, KingClaudin, LancelotBad, Balin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue,
+			SeniorMessenger, JuniorMessenger},
+		[]string{Ector, Polygraph, Stray},
+		[]string{Lot, Merlin, Morgana, Viviana, Gawain, Assassin, Mordred, BadAngel, KingClaudin,
+			QueenMab, Nerzhul, Mora, Melwas, Claudas, Maeve, MinionOfMordred, Lunatic, Brute, Revealer, Trickster, EvilSorcerer, EvilRogue,
+			SeniorMessenger, JuniorMessenger},
+		[]string{Meliagant},
+		[]string{SUCCESS, FAIL},
+		[]string{FAIL},
+		[]string{Percival, KingArthur},
+		[]string{Merlin},
+		"knows the other messengers and has one token. If he keeps it, he may name Merlin after the assassin",
+	},
 }
\ No newline at end of file
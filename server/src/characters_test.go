@@ -0,0 +1,330 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Characters(t *testing.T) {
+	t.Run("Builds the loyalty lists from the registry", should_build_loyalty_lists_from_registry)
+	t.Run("Offers the flush cards on a flush quest", should_offer_flush_cards_on_flush_quest)
+	t.Run("Lets a quest card hook override the registered cards", should_let_hook_override_cards)
+	t.Run("Answers the Lady from the registry", should_answer_lady_from_registry)
+	t.Run("Gives the Big Box evil roles their cards and answers", should_give_big_box_roles_their_rules)
+	t.Run("Reveals the Revealer after the second failed quest", should_reveal_revealer_after_second_fail)
+	t.Run("Shows the Cleric the first leader and the bads the Untrustworthy Servant", should_run_big_box_good_nights)
+	t.Run("Turns the Untrustworthy Servant bad when the Assassin names him", should_convert_untrustworthy_servant)
+	t.Run("Makes the Troublemaker lie to the Lady", should_make_troublemaker_lie_to_lady)
+	t.Run("Flips the quest result with a magic card", should_flip_result_with_magic)
+	t.Run("Shows Percival the Sorcerers and the bads the Evil Sorcerer", should_run_sorcerer_nights)
+	t.Run("Turns a magic card under Excalibur into the other side's card", should_turn_magic_with_excalibur)
+	t.Run("Offers the Rogue card once", should_offer_rogue_card_once)
+	t.Run("Announces a Rogue that won on their own", should_announce_rogue_win)
+}
+
+func should_build_loyalty_lists_from_registry(t *testing.T) {
+	//Arrange
+	rules := characterRules(Ginerva)
+
+	//Act
+	good, bad, neutral := goodCharacters[Merlin], badCharacters[Morgana], neutralCharacters[Ginerva]
+
+	//Assert
+	if !good || !bad || !neutral {
+		t.Error("Roles should be listed by their loyalty, got", good, bad, neutral)
+	}
+	if rules == nil || rules.Side != BAD {
+		t.Error("Ginerva should fill a bad seat, got", rules)
+	}
+	if characterRules("Nobody") != nil {
+		t.Error("An unknown role should have no rules")
+	}
+}
+
+func should_offer_flush_cards_on_flush_quest(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Percival, LoyalServentOfArthur, LoyalServentOfArthurA,
+		LoyalServentOfArthurB, LoyalServentOfArthurC, Assassin, Morgana, Mordred, Mora)
+
+	//Act
+	regular := board.getOptionalVotesAccordingToQuestMembers(Mora, map[string]bool{}, map[int]bool{}, 0, 10)
+	flush := board.getOptionalVotesAccordingToQuestMembers(Mora, map[string]bool{}, map[int]bool{}, 3, 10)
+
+	//Assert
+	if !reflect.DeepEqual(regular, []string{SUCCESS, FAIL}) || !reflect.DeepEqual(flush, []string{FAIL, SUCCESS}) {
+		t.Error("Mora should get her regular and flush cards, got", regular, flush)
+	}
+}
+
+func should_let_hook_override_cards(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+	members := map[string]bool{Merlin: true, Nimue: true}
+
+	//Act
+	withMerlin := board.getOptionalVotesAccordingToQuestMembers(Nimue, members, map[int]bool{}, 0, 5)
+	members[Galahad] = true
+	withGalahad := board.getOptionalVotesAccordingToQuestMembers(Nimue, members, map[int]bool{}, 0, 5)
+
+	//Assert
+	if !reflect.DeepEqual(withMerlin, []string{FAIL}) || !reflect.DeepEqual(withGalahad, []string{SUCCESS}) {
+		t.Error("Nimue should fail only with Merlin and without Galahad, got", withMerlin, withGalahad)
+	}
+}
+
+func should_answer_lady_from_registry(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin, Percival, QueenMab, LoyalServentOfArthur)
+
+	//Act
+	answers := board.getOptionalLoyalty(playerOf(board, QueenMab))
+
+	//Assert
+	if !reflect.DeepEqual(answers, []string{BAD, GOOD}) {
+		t.Error("Queen Mab may answer either loyalty, got", answers)
+	}
+}
+
+func should_give_big_box_roles_their_rules(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin, Percival, Trickster, LoyalServentOfArthur)
+	trickster := playerOf(board, Trickster)
+
+	//Act
+	lunatic := board.getOptionalVotesAccordingToQuestMembers(Lunatic, map[string]bool{}, map[int]bool{}, 0, 5)
+	bruteEarly := board.getOptionalVotesAccordingToQuestMembers(Brute, map[string]bool{}, map[int]bool{}, 2, 5)
+	bruteLate := board.getOptionalVotesAccordingToQuestMembers(Brute, map[string]bool{}, map[int]bool{}, 3, 5)
+	answers := board.getOptionalLoyalty(trickster)
+
+	//Assert
+	if !reflect.DeepEqual(lunatic, []string{FAIL}) {
+		t.Error("The Lunatic must fail, got", lunatic)
+	}
+	if !reflect.DeepEqual(bruteEarly, []string{SUCCESS, FAIL}) || !reflect.DeepEqual(bruteLate, []string{SUCCESS}) {
+		t.Error("The Brute may fail only the first three quests, got", bruteEarly, bruteLate)
+	}
+	if !reflect.DeepEqual(answers, []string{BAD, GOOD}) {
+		t.Error("The Trickster may lie to the Lady, got", answers)
+	}
+	if inspected := board.inspectedLoyalty(trickster); inspected != GOOD {
+		t.Error("The Trickster should lie to the other loyalty checks, got", inspected)
+	}
+	if CharactersDescriptionMap[Revealer].Loyalty != BAD || !badCharacters[Lunatic] {
+		t.Error("The Big Box roles should be described as bad")
+	}
+}
+
+func should_reveal_revealer_after_second_fail(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin, Percival, Revealer, LoyalServentOfArthur)
+	revealer, merlin, percival := playerOf(board, Revealer), playerOf(board, Merlin), playerOf(board, Percival)
+
+	//Act
+	playQuest(t, board, questTeam(board, revealer, merlin, percival), revealer)
+	afterFirst := len(board.GetGameState("alice").PublicRoles)
+	playQuest(t, board, questTeam(board, revealer, merlin, percival), revealer)
+
+	//Assert
+	if afterFirst != 0 {
+		t.Error("The Revealer should stay hidden after one failed quest")
+	}
+	if roles := board.GetGameState("alice").PublicRoles; roles[revealer] != Revealer {
+		t.Error("Everyone should see the Revealer, got", roles)
+	}
+}
+
+func should_run_big_box_good_nights(t *testing.T) {
+	//Arrange
+	board, config := seatTestGame(GameConfiguration{}, Cleric, UntrustworthyServant, Troublemaker, Assassin, Morgana)
+
+	//Act
+	err := board.StartGameHandler(config)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Game should start, got", err)
+	}
+	leader := board.PlayerNames[0].Player
+	cleric := board.SecretsMap[board.CharacterToPlayer[Cleric].Player]
+	seen := cleric.PlayersWithGoodCharacter
+	if board.inspectedLoyalty(leader) == BAD {
+		seen = cleric.PlayersWithBadCharacter
+	}
+	if leader != board.CharacterToPlayer[Cleric].Player && !sameStringSlice(seen, []string{leader}) {
+		t.Error("The Cleric should learn the loyalty of", leader, "got", *cleric)
+	}
+	servant := board.CharacterToPlayer[UntrustworthyServant].Player
+	morgana := board.SecretsMap[board.CharacterToPlayer[Morgana].Player]
+	if !sameStringSlice(morgana.PlayersWithBadCharacter, []string{servant, board.CharacterToPlayer[Assassin].Player}) {
+		t.Error("The bads should see the Untrustworthy Servant as bad, got", morgana.PlayersWithBadCharacter)
+	}
+	if board.inspectedLoyalty(board.CharacterToPlayer[Troublemaker].Player) != BAD {
+		t.Error("The Troublemaker should lie about his loyalty")
+	}
+}
+
+func should_convert_untrustworthy_servant(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin, UntrustworthyServant, Morgana, LoyalServentOfArthur)
+	merlin, servant := playerOf(board, Merlin), playerOf(board, UntrustworthyServant)
+	winQuests(t, board, merlin, servant, playerOf(board, LoyalServentOfArthur))
+	named := MurderMessageInternal{CharacterKill: Merlin, Rest: []PlayerNameMurder{{Player: servant, Ch: true}}}
+
+	//Act
+	board.HandleMurder(named)
+	afterServant := board.State
+	_, servantMurdered := board.PlayerToMurderInfo[servant]
+	board.HandleMurder(MurderMessageInternal{CharacterKill: Merlin, Rest: []PlayerNameMurder{{Player: merlin, Ch: true}}})
+
+	//Assert
+	if afterServant != MurdersAfterGoodVictory || board.convertedPlayers[servant] != BAD {
+		t.Error("The Servant should turn bad and the Assassin try again, got", afterServant, board.convertedPlayers)
+	}
+	if board.State != VictoryForBad {
+		t.Error("The second try should kill Merlin, got", board.State)
+	}
+	if servantMurdered {
+		t.Error("The Servant should not be marked murdered")
+	}
+	archive := board.GetGameState(merlin).Archive
+	murders := archive[len(archive)-1].Murders
+	if len(murders) != 2 || murders[0].Outcome != MurderConverted || murders[1].Outcome != MurderSucceeded {
+		t.Error("Both tries should be archived, got", murders)
+	}
+}
+
+func should_make_troublemaker_lie_to_lady(t *testing.T) {
+	//Arrange
+	board := newLadyTestRoom(t, &LadyConfiguration{PublicResult: true}, Merlin, Assassin, Percival, Morgana, Troublemaker)
+	troublemaker := playerOf(board, Troublemaker)
+	if err := board.LadySuggestHandler(troublemaker); err != nil {
+		t.Fatal("The Lady should go to the Troublemaker, got", err)
+	}
+
+	//Act
+	truth := board.LadyResponseHandler(1)
+	lie := board.LadyResponseHandler(0)
+
+	//Assert
+	if cerr, ok := truth.(*CommandError); !ok || cerr.Code != ErrInvalidVote {
+		t.Error("The Troublemaker should not answer GOOD, got", truth)
+	}
+	if lie != nil {
+		t.Error("The Troublemaker should answer BAD, got", lie)
+	}
+	if history := board.ladyOfTheLake.history; history[len(history)-1].Answer != BAD {
+		t.Error("Everyone should see the lie, got", history)
+	}
+}
+
+func should_flip_result_with_magic(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+
+	//Act
+	flipped := board.CalculateQuestResult([]int{VoteSuccess, VoteMagic})
+	failFlipped := board.CalculateQuestResult([]int{VoteFail, VoteMagic})
+	twice := board.CalculateQuestResult([]int{VoteMagic, VoteMagic})
+
+	//Assert
+	if flipped != JorneyFail || failFlipped != JorneySuccess || twice != JorneySuccess {
+		t.Error("Every magic card should flip the result, got", flipped, failFlipped, twice)
+	}
+}
+
+func should_run_sorcerer_nights(t *testing.T) {
+	//Arrange
+	board, config := seatTestGame(GameConfiguration{}, Merlin, Percival, GoodSorcerer, Assassin, EvilSorcerer)
+
+	//Act
+	err := board.StartGameHandler(config)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Game should start, got", err)
+	}
+	good, evil := board.CharacterToPlayer[GoodSorcerer].Player, board.CharacterToPlayer[EvilSorcerer].Player
+	percival := board.SecretsMap[board.CharacterToPlayer[Percival].Player]
+	if percival.PlayersWithUncoveredCharacters[good] != "Sorcerer" || percival.PlayersWithUncoveredCharacters[evil] != "Sorcerer" {
+		t.Error("Percival should see both Sorcerers, got", percival.PlayersWithUncoveredCharacters)
+	}
+	assassin := board.SecretsMap[board.CharacterToPlayer[Assassin].Player]
+	merlin := board.SecretsMap[board.CharacterToPlayer[Merlin].Player]
+	if !sameStringSlice(assassin.PlayersWithBadCharacter, []string{evil}) ||
+		!sameStringSlice(merlin.PlayersWithBadCharacter, []string{evil, board.CharacterToPlayer[Assassin].Player}) {
+		t.Error("The bads and Merlin should see the Evil Sorcerer, got", assassin.PlayersWithBadCharacter, merlin.PlayersWithBadCharacter)
+	}
+}
+
+func should_turn_magic_with_excalibur(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{Excalibur: true}, Merlin, Assassin, Percival, EvilSorcerer, LoyalServentOfArthur)
+	merlin, sorcerer := playerOf(board, Merlin), playerOf(board, EvilSorcerer)
+	board.HandleNewSuggest(Suggestion{Players: []string{merlin, sorcerer}, ExcaliburPlayer: merlin})
+	for _, player := range board.PlayerNames {
+		board.HandleSuggestionVote(VoteForSuggestion{PlayerName: player.Player, Vote: true})
+	}
+	board.HandleJourneyVote(VoteForJourney{PlayerName: merlin, Vote: VoteSuccess})
+	board.HandleJourneyVote(VoteForJourney{PlayerName: sorcerer, Vote: VoteMagic})
+	if board.State != ExcaliburPick {
+		t.Fatal("Excalibur should be picked, got", board.State)
+	}
+
+	//Act
+	board.ExcaliburHandler([]string{sorcerer})
+
+	//Assert
+	if res := board.quests.results[1]; res.NumOfMagic != 0 || res.NumOfSuccess != 2 || res.Final != JorneySuccess {
+		t.Error("The Evil Sorcerer's magic card should turn to Success, got", res)
+	}
+}
+
+func should_offer_rogue_card_once(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin, Percival, EvilRogue, LoyalServentOfArthur)
+	merlin, rogue := playerOf(board, Merlin), playerOf(board, EvilRogue)
+	flags := map[int]bool{}
+
+	//Act
+	before := board.getOptionalVotesAccordingToQuestMembers(EvilRogue, map[string]bool{}, flags, 0, 5)
+	voteTeam(t, board, []string{merlin, rogue})
+	board.HandleJourneyVote(VoteForJourney{PlayerName: rogue, Vote: VoteRogueFail})
+	after := board.getOptionalVotesAccordingToQuestMembers(EvilRogue, map[string]bool{}, board.quests.Flags, 0, 5)
+
+	//Assert
+	if !sameStringSlice(before, []string{ROGUE_FAIL, SUCCESS, FAIL}) || !sameStringSlice(after, []string{SUCCESS, FAIL}) {
+		t.Error("The Rogue Fail card should be offered until it is played, got", before, after)
+	}
+}
+
+func should_announce_rogue_win(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin, Percival, EvilRogue, LoyalServentOfArthur)
+	merlin, percival, rogue := playerOf(board, Merlin), playerOf(board, Percival), playerOf(board, EvilRogue)
+	voteTeam(t, board, []string{merlin, rogue})
+
+	//Act
+	board.HandleJourneyVote(VoteForJourney{PlayerName: merlin, Vote: VoteSuccess})
+	err := board.HandleJourneyVote(VoteForJourney{PlayerName: rogue, Vote: VoteRogueFail})
+	winQuests(t, board, merlin, percival, playerOf(board, LoyalServentOfArthur))
+	board.HandleMurder(MurderMessageInternal{CharacterKill: Merlin, Rest: []PlayerNameMurder{{Player: percival, Ch: true}}})
+	state := board.GetGameState(merlin)
+
+	//Assert
+	if err != nil {
+		t.Fatal("The Rogue should play the Rogue Fail card, got", err)
+	}
+	if board.quests.results[1].Final != JorneyFail || board.quests.results[1].NumOfRogueFail != 1 {
+		t.Error("The Rogue Fail card should fail the quest, got", board.quests.results[1])
+	}
+	if board.State != VictoryForGood {
+		t.Error("The Assassin should miss Merlin, got", board.State)
+	}
+	if len(state.AdditionalWinners) != 1 || state.AdditionalWinners[0].Player != rogue || state.AdditionalWinners[0].Quest != 1 {
+		t.Error("The Rogue should win on their own, got", state.AdditionalWinners)
+	}
+	if !strings.Contains(state.StateDescription, "also winning: "+rogue) {
+		t.Error("The description should announce the Rogue, got", state.StateDescription)
+	}
+}
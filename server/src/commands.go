@@ -0,0 +1,390 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+)
+
+// EnvelopeVersion is the current version of the command envelope.
+const EnvelopeVersion = 1
+
+// Error codes sent back in an error reply.
+const (
+	ErrBadMessage         = "bad_message"
+	ErrUnsupportedVersion = "unsupported_version"
+	ErrUnknownType        = "unknown_type"
+	ErrBadPayload         = "bad_payload"
+	ErrNotInRoom          = "not_in_room"
+	ErrWrongState         = "wrong_state"
+	ErrNotYourTurn        = "not_your_turn"
+	ErrIdentityMismatch   = "identity_mismatch"
+	ErrAlreadyVoted       = "already_voted"
+	ErrNotSeated          = "not_seated"
+	ErrNotOnQuest         = "not_on_quest"
+	ErrInvalidTeam        = "invalid_team"
+	ErrInvalidVote        = "invalid_vote"
+	ErrInvalidConfig      = "invalid_config"
+	ErrInvalidRoom        = "invalid_room"
+	ErrInvalidTarget      = "invalid_target"
+	ErrInternal           = "internal_error"
+)
+
+/*
+Envelope is a command sent by a client:
+
+	{"v": 1, "id": "<request id>", "type": "<command>", "payload": {...}}
+
+A message without "v" is a legacy message ({"type": ..., "content": ...}). Its content is used as the
+payload and it gets no reply, since the old client does not expect one.
+*/
+type Envelope struct {
+	Version int             `json:"v"`
+	Id      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+
+	Content json.RawMessage `json:"content,omitempty"` // legacy payload
+	Player  string          `json:"player,omitempty"`  // legacy add_player payload
+}
+
+// Reply answers an enveloped command. Type is "ack" or "error".
+type Reply struct {
+	Type    string `json:"ty"`
+	Version int    `json:"v"`
+	Id      string `json:"id,omitempty"`
+	Command string `json:"command,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// CommandError is a rejected command. Code is one of the Err consts.
+type CommandError struct {
+	Code    string
+	Message string
+}
+
+func (e *CommandError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func newCommandError(code string, message string) *CommandError {
+	return &CommandError{Code: code, Message: message}
+}
+
+// parseEnvelope reads both the versioned envelope and the legacy message format.
+func parseEnvelope(message []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return &envelope, newCommandError(ErrBadMessage, err.Error())
+	}
+	if envelope.Version == 0 {
+		envelope.Payload = envelope.Content
+		if envelope.Type == "add_player" && envelope.Payload == nil {
+			envelope.Payload, _ = json.Marshal(envelope.Player)
+		}
+	} else if envelope.Version != EnvelopeVersion {
+		return &envelope, newCommandError(ErrUnsupportedVersion, "envelope version must be 1")
+	}
+	if envelope.Type == "" {
+		return &envelope, newCommandError(ErrBadMessage, "type is missing")
+	}
+	return &envelope, nil
+}
+
+// decode unmarshals the payload into v. A missing payload leaves v untouched.
+func (envelope *Envelope) decode(v interface{}) error {
+	if len(envelope.Payload) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Payload, v); err != nil {
+		return newCommandError(ErrBadPayload, err.Error())
+	}
+	return nil
+}
+
+// Which board update follows a successful command.
+const (
+	BoardToAll = iota
+	BoardToSender
+	BoardToAllExceptSender
+	NoBoard
+)
+
+type command struct {
+	needsRoom bool
+	board     int
+	handle    func(c *Client, board *BoardGame, envelope *Envelope) error
+}
+
+// gameCommand is a command of the client's room. player is the authenticated identity of the socket.
+func gameCommand(board int, handle func(board *BoardGame, player string, envelope *Envelope) error) command {
+	return command{needsRoom: true, board: board, handle: func(c *Client, b *BoardGame, envelope *Envelope) error {
+		return handle(b, c.id, envelope)
+	}}
+}
+
+// claimIdentity checks a player name carried by a payload against the socket identity.
+// An empty name is taken as the socket identity.
+func claimIdentity(claimed string, player string) (string, error) {
+	if claimed != "" && claimed != player {
+		return "", newCommandError(ErrIdentityMismatch, player+" cannot act as "+claimed)
+	}
+	return player, nil
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"list_rooms":  {handle: handleListRooms},
+		"create_room": {handle: handleCreateRoom},
+		"join_room":   {handle: handleJoinRoom},
+		"leave_room":  {handle: handleLeaveRoom},
+
+		"set_protocol": {handle: handleSetProtocol},
+		"ack_state":    {handle: handleAckState},
+		"resync":       {handle: handleResync},
+
+		"chat_message": {needsRoom: true, board: NoBoard, handle: handleChatMessage},
+		"refresh":      {needsRoom: true, board: NoBoard, handle: handleRefresh},
+		"add_player":   {needsRoom: true, board: BoardToAll, handle: handleAddPlayer},
+		"reset": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			board.Reset()
+			return nil
+		}),
+		"start_game": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content GameConfiguration
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.StartGameHandler(content)
+		}),
+		"murder": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content MurderMessageInternal
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.HandleMurder(content)
+		}),
+		"declare_target": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content string
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.DeclareTargetHandler(content)
+		}),
+		"sir_pick": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content SirMessageInternal
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.HandleSir(content)
+		}),
+		"excalibur_pick": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content []string
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.ExcaliburHandler(content)
+		}),
+		"lady_suggest": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content string
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.LadySuggestHandler(content)
+		}),
+		"lady_response": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content int
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.LadyResponseHandler(content)
+		}),
+		"lady_publish_response": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content int
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.LadyPublishResponseHandler(content)
+		}),
+		"vote_for_suggestion": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content VoteForSuggestion
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			var err error
+			if content.PlayerName, err = claimIdentity(content.PlayerName, player); err != nil {
+				return err
+			}
+			return board.HandleSuggestionVote(content)
+		}),
+		"suggestion": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content Suggestion
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.HandleNewSuggest(content)
+		}),
+		"suggestion_tmp": gameCommand(BoardToAllExceptSender, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content []string
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.HandleTemporarySuggest(content)
+		}),
+		"plot_deal": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content []string
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			return board.PlotDealHandler(content)
+		}),
+		"plot_play": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content PlotPlay
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			var err error
+			if content.PlayerName, err = claimIdentity(content.PlayerName, player); err != nil {
+				return err
+			}
+			return board.PlotPlayHandler(content)
+		}),
+		"messenger_action": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content MessengerAction
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			var err error
+			if content.PlayerName, err = claimIdentity(content.PlayerName, player); err != nil {
+				return err
+			}
+			return board.MessengerActionHandler(content)
+		}),
+		"vote_for_journey": gameCommand(BoardToAll, func(board *BoardGame, player string, envelope *Envelope) error {
+			var content VoteForJourney
+			if err := envelope.decode(&content); err != nil {
+				return err
+			}
+			var err error
+			if content.PlayerName, err = claimIdentity(content.PlayerName, player); err != nil {
+				return err
+			}
+			return board.HandleJourneyVote(content)
+		}),
+	}
+}
+
+// handleCommand runs one command of this client and broadcasts the board update that follows it.
+func (c *Client) handleCommand(envelope *Envelope) error {
+	cmd, ok := commands[envelope.Type]
+	if !ok {
+		return newCommandError(ErrUnknownType, "unknown command "+envelope.Type)
+	}
+	board := c.room
+	if cmd.needsRoom && board == nil {
+		return newCommandError(ErrNotInRoom, "join a room first")
+	}
+	if err := c.runCommand(cmd, board, envelope); err != nil {
+		return err
+	}
+	if cmd.board == NoBoard || c.room == nil {
+		return nil
+	}
+	recipient := ""
+	if cmd.board == BoardToSender {
+		recipient = c.id
+	} else if cmd.board == BoardToAllExceptSender {
+		recipient = "^" + c.id
+	}
+	jsonMessage, _ := json.Marshal(&Message{Sender: c.id, Recipient: recipient, Content: "board"})
+	c.room.manager.broadcast <- jsonMessage
+	return nil
+}
+
+// runCommand runs a command, first checking it against the state machine when the machine governs it.
+func (c *Client) runCommand(cmd command, board *BoardGame, envelope *Envelope) error {
+	if board == nil || !gameMachine.governs(envelope.Type) {
+		return cmd.handle(c, board, envelope)
+	}
+	board.commandMutex.Lock()
+	defer board.commandMutex.Unlock()
+	board.mutex.RLock()
+	err := board.checkCommand(envelope.Type, c.id)
+	board.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	return cmd.handle(c, board, envelope)
+}
+
+// reply sends an ack or an error for an enveloped command. Legacy messages only get logged.
+func (c *Client) reply(envelope *Envelope, err error) {
+	if err != nil {
+		log.Println("client", c.id, "command", envelope.Type, "rejected:", err)
+	}
+	if envelope.Version != EnvelopeVersion {
+		if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrUnsupportedVersion {
+			return
+		}
+	}
+	reply := Reply{Type: "ack", Version: EnvelopeVersion, Id: envelope.Id, Command: envelope.Type}
+	if err != nil {
+		reply.Type = "error"
+		reply.Code = ErrBadMessage
+		reply.Message = err.Error()
+		if cerr, ok := err.(*CommandError); ok {
+			reply.Code = cerr.Code
+			reply.Message = cerr.Message
+		}
+	}
+	msg, _ := json.Marshal(&reply)
+	c.send.push(msg, false)
+}
+
+func handleChatMessage(c *Client, board *BoardGame, envelope *Envelope) error {
+	// the chat keeps the legacy shape, since clients parse "content" out of it
+	chat, _ := json.Marshal(struct {
+		Tp      string          `json:"type"`
+		Content json.RawMessage `json:"content,omitempty"`
+	}{envelope.Type, envelope.Payload})
+	jsonMessage, _ := json.Marshal(&Message{Sender: c.id, Content: string(chat)})
+	board.manager.broadcast <- jsonMessage
+	return nil
+}
+
+func handleRefresh(c *Client, board *BoardGame, envelope *Envelope) error {
+	board.mutex.RLock()
+	recipient := ""
+	if board.State != NotStarted {
+		recipient = c.id
+	}
+	board.mutex.RUnlock()
+	jsonMessage, _ := json.Marshal(&Message{Sender: c.id, Recipient: recipient, Content: "board"})
+	board.manager.broadcast <- jsonMessage
+	return nil
+}
+
+// handleAddPlayer seats the client. A client can only take its own seat.
+func handleAddPlayer(c *Client, board *BoardGame, envelope *Envelope) error {
+	var player string
+	if err := envelope.decode(&player); err != nil {
+		return err
+	}
+	player, err := claimIdentity(player, c.id)
+	if err != nil {
+		return err
+	}
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
+	newPlayer := PlayerName{player}
+	board.clientIdToPlayerName[c.id] = newPlayer
+	for _, p := range board.PlayerNames {
+		if p == newPlayer {
+			return nil
+		}
+	}
+	board.PlayerNames = append(board.PlayerNames, newPlayer)
+	return nil
+}
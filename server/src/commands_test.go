@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_Commands(t *testing.T) {
+	t.Run("Reads legacy messages as envelopes", should_read_legacy_message_as_envelope)
+	t.Run("Acks an enveloped command with its request id", should_ack_enveloped_command)
+	t.Run("Replies with an error code to a rejected command", should_reply_error_code_to_rejected_command)
+	t.Run("Rejects a quest vote outside of quest voting", should_reject_quest_vote_in_wrong_state)
+	t.Run("Rejects a game configuration with a wrong number of bads", should_reject_invalid_game_configuration)
+}
+
+func popReply(t *testing.T, c *Client) Reply {
+	message, ok := c.send.pop()
+	if !ok {
+		t.Fatal("Expected a reply")
+	}
+	var reply Reply
+	json.Unmarshal(message, &reply)
+	return reply
+}
+
+func should_read_legacy_message_as_envelope(t *testing.T) {
+	//Arrange
+	message := []byte(`{"type":"add_player","player":"alice"}`)
+
+	//Act
+	envelope, err := parseEnvelope(message)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Legacy message should be read, got", err)
+	}
+	var player string
+	envelope.decode(&player)
+	if envelope.Version != 0 || player != "alice" {
+		t.Error("Legacy add_player should carry the player as payload, got", envelope.Version, player)
+	}
+}
+
+func should_ack_enveloped_command(t *testing.T) {
+	//Arrange
+	c := &Client{id: "alice", send: newSendQueue(10), patch: newPatchState(false)}
+	envelope, _ := parseEnvelope([]byte(`{"v":1,"id":"r1","type":"list_rooms"}`))
+
+	//Act
+	c.reply(envelope, c.handleCommand(envelope))
+
+	//Assert
+	c.send.pop() // room list
+	reply := popReply(t, c)
+	if reply.Type != "ack" || reply.Id != "r1" || reply.Command != "list_rooms" {
+		t.Error("Expected an ack for r1, got", reply)
+	}
+}
+
+func should_reply_error_code_to_rejected_command(t *testing.T) {
+	//Arrange
+	c := &Client{id: "alice", send: newSendQueue(10), patch: newPatchState(false)}
+	envelope, _ := parseEnvelope([]byte(`{"v":1,"id":"r2","type":"vote_for_journey","payload":{"vote":1}}`))
+
+	//Act
+	c.reply(envelope, c.handleCommand(envelope))
+
+	//Assert
+	reply := popReply(t, c)
+	if reply.Type != "error" || reply.Id != "r2" || reply.Code != ErrNotInRoom {
+		t.Error("Expected not_in_room for r2, got", reply)
+	}
+}
+
+func should_reject_quest_vote_in_wrong_state(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+	board.HandleNewSuggest(Suggestion{Players: otherPlayers(board)[:2]})
+
+	//Act
+	err := board.checkCommand("vote_for_journey", board.leader())
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrWrongState {
+		t.Error("Expected wrong_state, got", err)
+	}
+}
+
+func should_reject_invalid_game_configuration(t *testing.T) {
+	//Arrange
+	board, _ := seatTestGame(GameConfiguration{}, classicRoles...)
+	config := GameConfiguration{Excalibur: true, Characters: []Ch{{Name: Merlin, Checked: true}, {Name: Assassin, Checked: true, Assassin: true}}}
+
+	//Act
+	err := board.StartGameHandler(config)
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidConfig {
+		t.Error("Expected invalid_config, got", err)
+	}
+	if board.State != NotStarted || len(board.quests.Flags) != 0 {
+		t.Error("A rejected configuration should not change the board")
+	}
+}
+
+func Test_Identity(t *testing.T) {
+	t.Run("Rejects a vote that claims another player", should_reject_vote_for_another_player)
+	t.Run("Seats only the socket's own identity", should_seat_only_own_identity)
+	t.Run("Rejects a suggestion from a player that is not the suggester", should_reject_suggestion_from_non_suggester)
+}
+
+func should_reject_vote_for_another_player(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+	board.HandleNewSuggest(Suggestion{Players: otherPlayers(board)[:2]})
+	c := &Client{id: "alice", room: board, send: newSendQueue(10), patch: newPatchState(false)}
+	envelope, _ := parseEnvelope([]byte(`{"v":1,"type":"vote_for_suggestion","payload":{"playerName":"bob","vote":true}}`))
+
+	//Act
+	err := c.handleCommand(envelope)
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrIdentityMismatch {
+		t.Error("Expected identity_mismatch, got", err)
+	}
+	if len(board.votesForNextMission) != 0 {
+		t.Error("No vote should be counted, got", board.votesForNextMission)
+	}
+}
+
+func should_seat_only_own_identity(t *testing.T) {
+	//Arrange
+	board := newTestRoom()
+	c := &Client{id: "alice", room: board, send: newSendQueue(10), patch: newPatchState(false)}
+
+	//Act
+	err := handleAddPlayer(c, board, &Envelope{Type: "add_player", Payload: []byte(`"mallory"`)})
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrIdentityMismatch {
+		t.Error("Expected identity_mismatch, got", err)
+	}
+	if len(board.PlayerNames) != 0 {
+		t.Error("No seat should be added, got", board.PlayerNames)
+	}
+}
+
+func should_reject_suggestion_from_non_suggester(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+
+	//Act
+	err := board.checkCommand("suggestion", otherPlayers(board)[0])
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrNotYourTurn {
+		t.Error("Expected not_your_turn, got", err)
+	}
+}
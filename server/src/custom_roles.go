@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// CustomRole is a house role read from the roles file, described like the built in roles.
+type CustomRole struct {
+	Name                 string `json:"name" yaml:"name"`
+	CharacterDescription `yaml:",inline"`
+	Script               string `json:"script,omitempty" yaml:"script,omitempty"` // Starlark source, see RoleScript
+}
+
+type customRolesFile struct {
+	Roles []CustomRole `json:"roles" yaml:"roles"`
+}
+
+var questCardNames = map[string]bool{FAIL: true, SUCCESS: true, REVERSAL: true, BEAST: true, EMPTY: true, AVALON_POWER: true, MAGIC: true,
+	ROGUE_SUCCESS: true, ROGUE_FAIL: true}
+
+// loadCustomRoles reads a JSON (.json) or YAML file of house roles and adds them to the registry.
+func loadCustomRoles(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var file customRolesFile
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&file)
+	} else {
+		err = yaml.UnmarshalStrict(data, &file)
+	}
+	if err != nil {
+		return errors.New(path + ": " + err.Error())
+	}
+	if err := registerCustomRoles(file.Roles); err != nil {
+		return errors.New(path + ": " + err.Error())
+	}
+	return nil
+}
+
+// registerCustomRoles validates all the roles first, so a bad file adds nothing.
+func registerCustomRoles(roles []CustomRole) error {
+	problems := validateCustomRoles(roles)
+	scripts := make(map[string]*RoleScript)
+	for _, role := range roles {
+		if role.Script == "" {
+			continue
+		}
+		script, err := compileRoleScript(role.Name, role.Script)
+		if err != nil {
+			problems = append(problems, role.Name+": script: "+err.Error())
+			continue
+		}
+		scripts[role.Name] = script
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	for _, role := range roles {
+		desc := role.CharacterDescription
+		rules := CharacterRules{Name: role.Name, Loyalty: desc.Loyalty, QuestCards: desc.CanVote,
+			FlushCards: desc.CanVoteOnFlush, SeesSpecifically: desc.CanSeeSpecifically, SeesAsColor: desc.CanSeeAsColor}
+		if script, ok := scripts[role.Name]; ok {
+			script.attach(&rules)
+		}
+		registerCharacter(rules)
+		CharactersDescriptionMap[role.Name] = desc
+	}
+
+	// the other side of every visibility rule, on built in roles too
+	for _, role := range roles {
+		for _, c := range role.CanSeeSpecifically {
+			describeSeenBy(c, role.Name, false)
+		}
+		for _, c := range role.CanSeeAsColor {
+			describeSeenBy(c, role.Name, true)
+		}
+		for _, c := range role.SeenSpecificallyBy {
+			rules := characterRules(c)
+			rules.SeesSpecifically = append(rules.SeesSpecifically, role.Name)
+			desc := CharactersDescriptionMap[c]
+			desc.CanSeeSpecifically = append(desc.CanSeeSpecifically, role.Name)
+			CharactersDescriptionMap[c] = desc
+		}
+		for _, c := range role.SeenAsColorBy {
+			rules := characterRules(c)
+			rules.SeesAsColor = append(rules.SeesAsColor, role.Name)
+			desc := CharactersDescriptionMap[c]
+			desc.CanSeeAsColor = append(desc.CanSeeAsColor, role.Name)
+			CharactersDescriptionMap[c] = desc
+		}
+	}
+	return nil
+}
+
+func describeSeenBy(character string, by string, asColor bool) {
+	desc := CharactersDescriptionMap[character]
+	if asColor {
+		desc.SeenAsColorBy = append(desc.SeenAsColorBy, by)
+	} else {
+		desc.SeenSpecificallyBy = append(desc.SeenSpecificallyBy, by)
+	}
+	CharactersDescriptionMap[character] = desc
+}
+
+func validateCustomRoles(roles []CustomRole) []string {
+	problems := make([]string, 0)
+	names := make(map[string]bool)
+	for _, role := range roles {
+		if role.Name == "" {
+			problems = append(problems, "a role has no name")
+			continue
+		}
+		if characterRules(role.Name) != nil || names[role.Name] {
+			problems = append(problems, role.Name+": the role already exists")
+		}
+		names[role.Name] = true
+	}
+
+	for _, role := range roles {
+		if role.Name == "" {
+			continue
+		}
+		if role.Loyalty != GOOD && role.Loyalty != BAD && role.Loyalty != NEUTRAL {
+			problems = append(problems, role.Name+": loyalty must be "+GOOD+", "+BAD+" or "+NEUTRAL)
+		}
+		references := [][]string{role.CanSeeAsColor, role.CanSeeSpecifically, role.SeenAsColorBy, role.SeenSpecificallyBy}
+		for _, list := range references {
+			for _, c := range list {
+				if characterRules(c) == nil && !names[c] {
+					problems = append(problems, role.Name+": unknown role "+c)
+				}
+			}
+		}
+		for _, card := range append(append([]string{}, role.CanVote...), role.CanVoteOnFlush...) {
+			if !questCardNames[card] {
+				problems = append(problems, role.Name+": unknown quest card "+card)
+			}
+		}
+		if len(role.Murder) > 0 || len(role.MurderedBy) > 0 {
+			problems = append(problems, role.Name+": custom roles cannot take part in murders")
+		}
+	}
+	return problems
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_CustomRoles(t *testing.T) {
+	t.Run("Loads roles from a YAML file and plays them", should_load_and_play_custom_roles)
+	t.Run("Rejects a file that references an unknown role", should_reject_unknown_role_reference)
+}
+
+func should_load_and_play_custom_roles(t *testing.T) {
+	//Arrange
+	path := filepath.Join(t.TempDir(), "roles.yaml")
+	os.WriteFile(path, []byte(`roles:
+  - name: Test-Hermit
+    loyalty: Good
+    canSeeAsColor: [Morgana]
+  - name: Test-Witch
+    loyalty: Bad
+    seenSpecificallyBy: [Test-Hermit]
+    canVoteOnFlush: [Fail, Success]
+`), 0644)
+
+	//Act
+	err := loadCustomRoles(path)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Roles file should load, got", err)
+	}
+	board := startTestGame(t, GameConfiguration{}, "Test-Hermit", Merlin, LoyalServentOfArthur, Morgana, "Test-Witch")
+	hermit := board.SecretsMap[board.CharacterToPlayer["Test-Hermit"].Player]
+	witch := board.CharacterToPlayer["Test-Witch"].Player
+	morgana := board.CharacterToPlayer[Morgana].Player
+	if hermit.PlayersWithUncoveredCharacters[witch] != "Test-Witch" || len(hermit.PlayersWithBadCharacter) != 1 ||
+		hermit.PlayersWithBadCharacter[0] != morgana {
+		t.Error("The hermit should see the witch and Morgana's colour, got", hermit)
+	}
+}
+
+func should_reject_unknown_role_reference(t *testing.T) {
+	//Arrange
+	roles := []CustomRole{{Name: "Test-Ghost", CharacterDescription: CharacterDescription{Loyalty: GOOD,
+		CanSeeSpecifically: []string{"Nobody"}}}}
+
+	//Act
+	err := registerCustomRoles(roles)
+
+	//Assert
+	if err == nil {
+		t.Error("Expected an unknown role error")
+	}
+	if characterRules("Test-Ghost") != nil {
+		t.Error("A rejected file should not add roles")
+	}
+}
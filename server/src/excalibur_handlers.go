@@ -11,56 +11,56 @@ type Excalibur struct {
 	ChosenPlayerVote int    `json:"vote,omitempty"`
 }
 
-type ExcaliburMessage struct {
-	Tp      string   `json:"type"`
-	Content []string `json:"content"`
-}
-
-func ExcaliburHandler(excaliburPick []string) {
+func (board *BoardGame) ExcaliburHandler(excaliburPick []string) error {
 	log.Println("got new excalibur pick:", excaliburPick)
-	globalMutex.Lock()
-	defer globalMutex.Unlock()
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
 
-	if globalBoard.State != ExcaliburPick {
-		return
-	}
+	current := board.quests.current
+	mp := board.quests.playersVotes[current]
+	res := board.quests.results[current+1]
+	curEntry := board.archive[len(board.archive)-1] //Stats table
 
-	current := globalBoard.quests.current
-	mp := globalBoard.quests.playersVotes[current]
-	res := globalBoard.quests.results[current+1]
-	curEntry := globalBoard.archive[len(globalBoard.archive)-1] //Stats table
-
-	globalBoard.StateDescription = "Excalibur: " + globalBoard.suggestions.excalibur.Player +
+	board.StateDescription = "Excalibur: " + board.suggestions.excalibur.Player +
 		" chosed player to change his vote..."
 
 	if len(excaliburPick) == 1 {
-		character := globalBoard.PlayerToCharacter[PlayerName{excaliburPick[0]}]
+		character := board.PlayerToCharacter[PlayerName{excaliburPick[0]}]
 		curEntry.ExcaliburChosenPlayer = excaliburPick[0]
-		playerVote := globalBoard.quests.playerVotedForCurrent[excaliburPick[0]]
-		globalBoard.suggestions.excalibur.ChosenPlayerVote = playerVote
-		globalBoard.Secrets[globalBoard.suggestions.excalibur.Player] = append(globalBoard.Secrets[globalBoard.suggestions.excalibur.Player], excaliburPick[0]+" voted "+getVoteStr(playerVote)+"(Quest "+strconv.FormatFloat(float64(curEntry.Id), 'f', 2, 32)+")")
+		playerVote := board.quests.playerVotedForCurrent[excaliburPick[0]]
+		board.suggestions.excalibur.ChosenPlayerVote = playerVote
+		board.Secrets[board.suggestions.excalibur.Player] = append(board.Secrets[board.suggestions.excalibur.Player], excaliburPick[0]+" voted "+getVoteStr(playerVote)+"(Quest "+strconv.FormatFloat(float64(curEntry.Id), 'f', 2, 32)+")")
 
 		if character != Maeve {
 			var newVote int
 			log.Println("character:", character, "player vote:", playerVote)
-			if playerVote == VoteReversal {
-				res.NumOfReversal--
-				curEntry.NumberOfReversal--
-				if character == GoodAngel {
-					res.NumOfFailures++
-					curEntry.NumberOfFailures++
-					log.Println("new vote fail")
-					newVote = VoteFail
-				} else if character == BadAngel {
+			if playerVote == VoteReversal || playerVote == VoteMagic {
+				if playerVote == VoteReversal {
+					res.NumOfReversal--
+					curEntry.NumberOfReversal--
+				} else {
+					res.NumOfMagic--
+					curEntry.NumberOfMagic--
+				}
+				/* A flipping card becomes the card of the other side: a good's turns to Fail, a bad's to Success */
+				if badCharacters[character] {
 					res.NumOfSuccess++
 					curEntry.NumberOfSuccesses++
 					log.Println("new vote success")
 					newVote = VoteSuccess
+				} else {
+					res.NumOfFailures++
+					curEntry.NumberOfFailures++
+					log.Println("new vote fail")
+					newVote = VoteFail
 				}
-			} else if playerVote == VoteFail || playerVote == VoteBeast {
+			} else if playerVote == VoteFail || playerVote == VoteBeast || playerVote == VoteRogueFail {
 				if playerVote == VoteFail {
 					res.NumOfFailures--
 					curEntry.NumberOfFailures--
+				} else if playerVote == VoteRogueFail {
+					res.NumOfRogueFail--
+					curEntry.NumberOfRogueFailures--
 				} else {
 					res.NumOfBeasts--
 					curEntry.NumberOfBeasts--
@@ -69,9 +69,14 @@ func ExcaliburHandler(excaliburPick []string) {
 				log.Println("new vote success")
 				res.NumOfSuccess++
 				curEntry.NumberOfSuccesses++
-			} else if playerVote == VoteSuccess {
-				res.NumOfSuccess--
-				curEntry.NumberOfSuccesses--
+			} else if playerVote == VoteSuccess || playerVote == VoteRogueSuccess {
+				if playerVote == VoteSuccess {
+					res.NumOfSuccess--
+					curEntry.NumberOfSuccesses--
+				} else {
+					res.NumOfRogueSuccess--
+					curEntry.NumberOfRogueSuccesses--
+				}
 				curEntry.NumberOfFailures++
 				res.NumOfFailures++
 				newVote = VoteFail
@@ -90,17 +95,18 @@ func ExcaliburHandler(excaliburPick []string) {
 					break
 				}
 			}
-			globalBoard.quests.playerVotedForCurrent[excaliburPick[0]] = newVote
+			board.quests.playerVotedForCurrent[excaliburPick[0]] = newVote
 		}
 
 		/* If we have Avalon Power, cancel this quest. */
-		if StartNewSuggestion(mp, curEntry, current) {
-			return
+		if board.StartNewSuggestion(mp, curEntry, current) {
+			return nil
 		}
 	}
-	EndJourney(&res, mp, &curEntry, current)
-	globalBoard.archive[len(globalBoard.archive)-1] = curEntry
-	globalBoard.quests.results[current+1] = res
-	globalBoard.quests.playersVotes[current] = mp
-	globalBoard.quests.current++
+	board.EndJourney(&res, mp, &curEntry, current)
+	board.archive[len(board.archive)-1] = curEntry
+	board.quests.results[current+1] = res
+	board.quests.playersVotes[current] = mp
+	board.quests.current++
+	return nil
 }
@@ -10,43 +10,29 @@ type SecretResponse struct {
 	PlayersWithCharacters  map[string]string `json:"uncoveredplayers,omitempty"`  //for vivian
 }
 
-func GetNightSecretsFromPlayerName(player PlayerName) SecretResponse {
+func (board *BoardGame) GetNightSecretsFromPlayerName(player PlayerName) SecretResponse {
 	response := SecretResponse{}
 
 	if player.Player == "" {
 		return SecretResponse{}
 	}
 
-	character := globalBoard.PlayerToCharacter[player]
+	character := board.PlayerToCharacter[player]
 	response.Character = character
-	response.Secrets = globalBoard.Secrets[player.Player]
+	response.Secrets = board.Secrets[player.Player]
 
 	if character == Viviana {
-		log.Println(globalBoard.PlayersWithBadCharacter)
-		log.Println(globalBoard.playersWithGoodCharacter)
-		response.PlayersWithBadCharacter = globalBoard.PlayersWithBadCharacter
-		response.PlayersWithGoodCharacter = globalBoard.playersWithGoodCharacter
-		response.PlayersWithCharacters = globalBoard.playersWithCharacters
-		response.Secrets = globalBoard.Secrets[player.Player]
+		log.Println(board.PlayersWithBadCharacter)
+		log.Println(board.playersWithGoodCharacter)
+		response.PlayersWithBadCharacter = board.PlayersWithBadCharacter
+		response.PlayersWithGoodCharacter = board.playersWithGoodCharacter
+		response.PlayersWithCharacters = board.playersWithCharacters
+		response.Secrets = board.Secrets[player.Player]
 	}
 	return response
 }
 
 
-func getTypeOfLevel(levelNum int, numOfPlayers int) int {
-	log.Println(levelNum, numOfPlayers)
-	if numOfPlayers <= 6 {
-		return RegularQuest
-	}
-	if levelNum == 4 {
-		return FlushQuest
-	} else if globalConfigPerNumOfPlayers[numOfPlayers].NumOfQuests-1 == levelNum {
-		return TwoFailsRequiredQuest
-	}
-	return RegularQuest
-
-}
-
 type PlayerInfo struct {
 	Character  string `json:"ch,omitempty"`
 	IsKilled   bool   `json:"isKilled,omitempty"`
@@ -83,6 +69,14 @@ type GameState struct {
 	PlayersVotedYes           []string                        `json:"PlayersVotedYesForSuggestion,omitempty"`
 	PlayersVotedNo            []string                        `json:"PlayersVotedNoForSuggestion,omitempty"`
 	Results                   map[int]QuestStats              `json:"results,omitempty"`
+	Board                     BoardConfiguration              `json:"board"`
+	HouseRules                gameRules                       `json:"houseRules"`
+	PublicRoles               map[string]string               `json:"publicRoles,omitempty"` // player -> role, known to everyone
+	ConvertedPlayers          map[string]string               `json:"convertedPlayers,omitempty"` // player -> new loyalty
+	PlotCards                 *PlotCardsView                  `json:"plotCards,omitempty"`
+	AdditionalWinners         []IndividualWin                 `json:"additionalWinners,omitempty"` // players that won on their own
+	RoleHistory               []IdentityChange                `json:"roleHistory,omitempty"` // who held which role when, once the game is over
+	LancelotDeck              []string                        `json:"lancelotDeck,omitempty"`
 	PlayerInfo                map[string]PlayerInfo           `json:"playerToCharacters,omitempty"`
 	IsExcalibur               bool                            `json:"excalibur,omitempty"`
 	SuggestedExcalibur        string                          `json:"suggestedExcalibur,omitempty"`
@@ -93,159 +87,190 @@ type GameState struct {
 	LadyResponseOptions       []string                        `json:"ladyResponseOptions,omitempty"` //lady of the lake
 	LadyPublish               string                          `json:"ladyPublish,omitempty"`         //lady of the lake
 	LadyPreviousSuggester               string                `json:"ladyPreviousSuggester,omitempty"`     //lady of the lake
+	LadyHistory               []LadyLedgerEntry               `json:"ladyHistory,omitempty"`         //lady of the lake
+	Connections               map[string]SeatConnection       `json:"connections,omitempty"` //player -> connection status and last seen
+	Seed                      int64                           `json:"seed,omitempty"`        //revealed when the game is over, to replay it
 }
 
-func GetGameState(clientId string) GameState {
-	globalMutex.RLock()
-	board := GameState{}
+func (board *BoardGame) GetGameState(clientId string) GameState {
+	board.mutex.RLock()
+	gameState := GameState{}
 
 	if clientId == "" {
-		return board
+		return gameState
 	}
 
 	log.Println("GetGameState:", clientId)
 
-	if globalBoard.State > NotStarted && globalBoard.SecretsMap[clientId] != nil {
-		log.Println(*globalBoard.SecretsMap[clientId])
-		board.PlayerSecrets = *globalBoard.SecretsMap[clientId]
+	if board.State > NotStarted && board.SecretsMap[clientId] != nil {
+		log.Println(*board.SecretsMap[clientId])
+		gameState.PlayerSecrets = *board.SecretsMap[clientId]
 	}
 	// Seer's two options to see: the player before or after.
-	if globalBoard.State == SirPickPlayer && globalBoard.CharacterToPlayer[Seer].Player == clientId {
-		seerOptions := getSeerOptions(clientId)
-		board.SirPick = SirPick{Options: seerOptions}
+	if board.State == SirPickPlayer && board.CharacterToPlayer[Seer].Player == clientId {
+		seerOptions := board.getSeerOptions(clientId)
+		gameState.SirPick = SirPick{Options: seerOptions}
 	}
 
-	if globalBoard.quests.Flags[EXCALIBUR] {
-		board.IsExcalibur = true
-		board.SuggestedExcalibur = globalBoard.suggestions.excalibur.Player
+	if board.quests.Flags[EXCALIBUR] {
+		gameState.IsExcalibur = true
+		gameState.SuggestedExcalibur = board.suggestions.excalibur.Player
 	}
-	if globalBoard.quests.Flags[LADY] {
-		board.IsLady = true
-		board.LadySuggester = globalBoard.ladyOfTheLake.currentSuggester
-		board.LadyChosenPlayer = globalBoard.ladyOfTheLake.currentChosenPlayer
-		if globalBoard.ladyOfTheLake.currentSuggester == clientId && globalBoard.State == LadySuggesterPublishResponseToWorld {
-			log.Println("response: ", globalBoard.ladyOfTheLake.ladyResponse)
-			if globalBoard.ladyOfTheLake.ladyResponse == 0 {
-				board.LadyResponse = "Bad"
-			} else if globalBoard.ladyOfTheLake.ladyResponse == 1 {
-				board.LadyResponse = "Good"
+	if board.quests.Flags[LADY] {
+		gameState.IsLady = true
+		gameState.LadySuggester = board.ladyOfTheLake.currentSuggester
+		gameState.LadyChosenPlayer = board.ladyOfTheLake.currentChosenPlayer
+		if board.ladyOfTheLake.currentSuggester == clientId && board.State == LadySuggesterPublishResponseToWorld {
+			log.Println("response: ", board.ladyOfTheLake.ladyResponse)
+			if board.ladyOfTheLake.ladyResponse == 0 {
+				gameState.LadyResponse = "Bad"
+			} else if board.ladyOfTheLake.ladyResponse == 1 {
+				gameState.LadyResponse = "Good"
 			}
 		} else {
-			log.Println(globalBoard.ladyOfTheLake.currentSuggester, clientId)
+			log.Println(board.ladyOfTheLake.currentSuggester, clientId)
 		}
-		board.LadyResponseOptions = getOptionalLoyalty(clientId)
-		board.LadyPreviousSuggester = globalBoard.ladyOfTheLake.previousSuggester
+		gameState.LadyResponseOptions = board.getOptionalLoyalty(clientId)
+		gameState.LadyPreviousSuggester = board.ladyOfTheLake.previousSuggester
+		gameState.LadyHistory = board.ladyOfTheLake.history
 	}
-	board.SuggestedTemporaryPlayers = globalBoard.suggestions.SuggestedTemporaryPlayers
-	board.Players.Total = len(globalBoard.PlayerNames)
-	board.Players.Players = globalBoard.PlayerNames
+	gameState.SuggestedTemporaryPlayers = board.suggestions.SuggestedTemporaryPlayers
+	gameState.Players.Total = len(board.PlayerNames)
+	gameState.Players.Players = board.PlayerNames
 	players := make([]PlayerName, 0)
-	for _, p := range globalBoard.PlayerNames {
-		if globalBoard.PlayerToCharacter[p] != Ector {
+	for _, p := range board.PlayerNames {
+		if board.PlayerToCharacter[p] != Ector {
 			players = append(players, p)
 		}
 	}
-	board.Players.Active = players
-	board.SuggestedPlayers = globalBoard.suggestions.SuggestedPlayers
-	board.CurrentQuest = globalBoard.quests.current + 1
-	board.NumOfActivePlayers = globalBoard.numOfPlayers
-	board.CurrentQuest = globalBoard.quests.current + 1
-	board.Size = globalConfigPerNumOfPlayers[globalBoard.numOfPlayers].NumOfQuests
-	board.Results = globalBoard.quests.results
-	board.Characters = make(map[string]CharacterDescription)
-	str, ok := globalBoard.CharacterToPlayer[Stray]
-	strayNewCharacter := globalBoard.PlayerToCharacter[str]
-	for _, ch := range globalBoard.Characters {
+	gameState.Players.Active = players
+	gameState.SuggestedPlayers = board.suggestions.SuggestedPlayers
+	gameState.CurrentQuest = board.quests.current + 1
+	gameState.NumOfActivePlayers = board.numOfPlayers
+	gameState.CurrentQuest = board.quests.current + 1
+	gameState.Size = board.layout.NumOfQuests
+	gameState.Board = board.layout
+	gameState.HouseRules = board.rules
+	gameState.PublicRoles = board.publicRoles
+	gameState.ConvertedPlayers = board.convertedPlayers
+	gameState.PlotCards = board.plotCardsView()
+	if board.hasLancelot() {
+		gameState.LancelotDeck = board.lancelotDeckView()
+	}
+	gameState.Results = board.quests.results
+	gameState.Characters = make(map[string]CharacterDescription)
+	str, ok := board.CharacterToPlayer[Stray]
+	strayNewCharacter := board.PlayerToCharacter[str]
+	for _, ch := range board.Characters {
 		if ok && Stray == ch && str.Player == clientId {
-			board.Characters[ch] = globalBoard.OtherRolesDescriptions[strayNewCharacter]
+			gameState.Characters[ch] = board.OtherRolesDescriptions[strayNewCharacter]
 		} else {
-			board.Characters[ch] = globalBoard.OtherRolesDescriptions[ch]
+			gameState.Characters[ch] = board.OtherRolesDescriptions[ch]
 		}
 	}
-	board.PlayersVotedForCurrQuest = globalBoard.quests.playerVotedForCurrentQuest
-	board.SuggesterVeto = globalBoard.suggestions.PlayerWithVeto
-	cpy := make([]QuestArchiveItem, len(globalBoard.archive))
-	copy(cpy, globalBoard.archive)
-	if len(cpy) > 0 && globalBoard.State == SuggestionVoting {
+	gameState.PlayersVotedForCurrQuest = board.quests.playerVotedForCurrentQuest
+	gameState.SuggesterVeto = board.suggestions.PlayerWithVeto
+	cpy := make([]QuestArchiveItem, len(board.archive))
+	copy(cpy, board.archive)
+	if len(cpy) > 0 && board.State == SuggestionVoting {
 		cpy[len(cpy)-1].PlayersVotedYes = make([]string, 0)
 		cpy[len(cpy)-1].PlayersVotedNo = make([]string, 0)
 	}
-	if len(cpy) > 0 && (globalBoard.State == JorneyVoting || globalBoard.State == ExcaliburPick) {
+	if len(cpy) > 0 && (board.State == JorneyVoting || board.State == ExcaliburPick) {
 		cpy[len(cpy)-1].NumberOfReversal = 0
 		cpy[len(cpy)-1].NumberOfSuccesses = 0
 		cpy[len(cpy)-1].NumberOfFailures = 0
 		cpy[len(cpy)-1].NumberOfBeasts = 0
 		cpy[len(cpy)-1].NumberOfEmpty = 0
+		cpy[len(cpy)-1].NumberOfMagic = 0
+		cpy[len(cpy)-1].NumberOfRogueSuccesses = 0
+		cpy[len(cpy)-1].NumberOfRogueFailures = 0
 	}
-	board.Archive = cpy
+	gameState.Archive = cpy
 
 	if clientId == Meliagant {
-		board.OnlyGoodSuggested = globalBoard.suggestions.OnlyGoodSuggested
+		gameState.OnlyGoodSuggested = board.suggestions.OnlyGoodSuggested
 	}
-	board.State = globalBoard.State
-	board.StateDescription = globalBoard.StateDescription
-	board.Secrets = GetNightSecretsFromPlayerName(PlayerName{clientId})
-	board.OptionalVotes = getOptionalVotesAccordingToQuestMembers(globalBoard.PlayerToCharacter[PlayerName{clientId}], globalBoard.suggestions.SuggestedCharacters, globalBoard.quests.Flags, globalBoard.quests.current, globalBoard.numOfPlayers)
-	board.PlayersVotedYes = globalBoard.suggestions.playersVotedYes
-	board.PlayersVotedNo = globalBoard.suggestions.playersVotedNo
-	if len(globalBoard.PlayerNames) > 0 {
-		board.Suggester = globalBoard.PlayerNames[globalBoard.suggestions.suggesterIndex%len(globalBoard.PlayerNames)].Player
+	gameState.State = board.State
+	gameState.StateDescription = board.StateDescription
+	gameState.Secrets = board.GetNightSecretsFromPlayerName(PlayerName{clientId})
+	gameState.OptionalVotes = board.getOptionalVotesAccordingToQuestMembers(board.PlayerToCharacter[PlayerName{clientId}], board.suggestions.SuggestedCharacters, board.quests.Flags, board.quests.current, board.numOfPlayers)
+	gameState.PlayersVotedYes = board.suggestions.playersVotedYes
+	gameState.PlayersVotedNo = board.suggestions.playersVotedNo
+	if len(board.PlayerNames) > 0 {
+		gameState.Suggester = board.PlayerNames[board.suggestions.suggesterIndex%len(board.PlayerNames)].Player
 	}
-	if globalBoard.State == MurdersAfterBadVictory || globalBoard.State == MurdersAfterGoodVictory {
-		board.Murder.TargetCharacters = globalBoard.PendingMurders[0].TargetCharacters
-		board.Murder.By = globalBoard.PendingMurders[0].By
-		board.Murder.ByCharacter = globalBoard.PendingMurders[0].ByCharacter
+	if board.State == MurdersAfterBadVictory || board.State == MurdersAfterGoodVictory {
+		gameState.Murder.TargetCharacters = board.PendingMurders[0].TargetCharacters
+		gameState.Murder.By = board.PendingMurders[0].By
+		gameState.Murder.ByCharacter = board.PendingMurders[0].ByCharacter
+		gameState.Murder.Declared = board.PendingMurders[0].Declared
 	}
 
 	//Here we can expose character in the player list!!!
 
-	if globalBoard.PlayerToCharacter[PlayerName{clientId}] == Viviana {
-		board.PlayerInfo = make(map[string]PlayerInfo)
-		for p, c := range globalBoard.playersWithCharacters {
+	if board.PlayerToCharacter[PlayerName{clientId}] == Viviana {
+		gameState.PlayerInfo = make(map[string]PlayerInfo)
+		for p, c := range board.playersWithCharacters {
 			playerInfo := PlayerInfo{}
 			playerInfo.Character = c
-			board.PlayerInfo[p] = playerInfo
+			gameState.PlayerInfo[p] = playerInfo
 		}
 	}
 
-	ectorName, hasEctor := globalBoard.CharacterToPlayer[Ector]
-	if hasEctor || isGameOver() {
-		board.PlayerInfo = make(map[string]PlayerInfo)
-		if isGameOver() {
-			for _, pl := range board.Players.Players {
+	ectorName, hasEctor := board.CharacterToPlayer[Ector]
+	if hasEctor || board.isGameOver() {
+		gameState.PlayerInfo = make(map[string]PlayerInfo)
+		if board.isGameOver() {
+			for _, pl := range gameState.Players.Players {
 				playerInfo := PlayerInfo{}
-				playerInfo.Character = globalBoard.PlayerToCharacter[pl]
-				_, isKilled := globalBoard.PlayerToMurderInfo[pl.Player]
+				playerInfo.Character = board.PlayerToCharacter[pl]
+				_, isKilled := board.PlayerToMurderInfo[pl.Player]
 				playerInfo.IsKilled = isKilled
-				board.PlayerInfo[pl.Player] = playerInfo
+				gameState.PlayerInfo[pl.Player] = playerInfo
 			}
 		}
 		if hasEctor {
 			playerInfo := PlayerInfo{Character: Ector, IsKilled: false}
-			board.PlayerInfo[ectorName.Player] = playerInfo
+			gameState.PlayerInfo[ectorName.Player] = playerInfo
 		}
 	}
-	if globalBoard.State == MurdersAfterBadVictory || globalBoard.State == MurdersAfterGoodVictory {
-		dagonetName, hasDagonet := globalBoard.CharacterToPlayer[Dagonet]
+	if board.isGameOver() {
+		gameState.Seed = board.Seed
+		gameState.AdditionalWinners = board.individualWins
+		gameState.RoleHistory = board.identityChanges
+		gameState.StateDescription = board.announceIndividualWins(gameState.StateDescription)
+	}
+	if board.State == MurdersAfterBadVictory || board.State == MurdersAfterGoodVictory {
+		dagonetName, hasDagonet := board.CharacterToPlayer[Dagonet]
 		if hasDagonet {
-			if board.PlayerInfo == nil {
-				board.PlayerInfo = make(map[string]PlayerInfo)
+			if gameState.PlayerInfo == nil {
+				gameState.PlayerInfo = make(map[string]PlayerInfo)
 			}
 			playerInfo := PlayerInfo{Character: Dagonet, IsKilled: false}
-			board.PlayerInfo[dagonetName.Player] = playerInfo
+			gameState.PlayerInfo[dagonetName.Player] = playerInfo
 		}
 	}
-	globalMutex.RUnlock()
-	return board
+	gameState.Connections = make(map[string]SeatConnection)
+	for player, status := range board.connections {
+		gameState.Connections[player] = status
+	}
+	board.mutex.RUnlock()
+	return gameState
+}
+
+// isSeatingOpen tells whether players may take or free seats, i.e. no game is running.
+func (board *BoardGame) isSeatingOpen() bool {
+	return board.State == NotStarted || board.isGameOver()
 }
 
-func isGameOver() bool {
-	return globalBoard.State == VictoryForSirGawain || globalBoard.State == VictoryForGawain || globalBoard.State == VictoryForGood || globalBoard.State == VictoryForBad
+func (board *BoardGame) isGameOver() bool {
+	return board.State == VictoryForSirGawain || board.State == VictoryForGawain || board.State == VictoryForGood || board.State == VictoryForBad
 }
 
-func getSeerOptions(clientId string) []string {
+func (board *BoardGame) getSeerOptions(clientId string) []string {
 	var seerIndex int
-	for i, p := range globalBoard.PlayerNames {
+	for i, p := range board.PlayerNames {
 		if p.Player == clientId {
 			seerIndex = i
 		}
@@ -253,14 +278,25 @@ func getSeerOptions(clientId string) []string {
 
 	indexBeforeSeer := seerIndex - 1
 	if indexBeforeSeer < 0 {
-		indexBeforeSeer = len(globalBoard.PlayerNames) - 1
+		indexBeforeSeer = len(board.PlayerNames) - 1
 	}
 
 	indexAfterSeer := seerIndex + 1
-	if indexAfterSeer > len(globalBoard.PlayerNames)-1 {
+	if indexAfterSeer > len(board.PlayerNames)-1 {
 		indexAfterSeer = 0
 	}
-	seerOptions := []string{globalBoard.PlayerNames[indexBeforeSeer].Player,
-		globalBoard.PlayerNames[indexAfterSeer].Player}
+	seerOptions := []string{board.PlayerNames[indexBeforeSeer].Player,
+		board.PlayerNames[indexAfterSeer].Player}
 	return seerOptions
 }
+
+// revealToAll shows the role of player to everyone, once.
+func (board *BoardGame) revealToAll(player string, character string) {
+	if board.publicRoles == nil {
+		board.publicRoles = make(map[string]string)
+	}
+	if _, ok := board.publicRoles[player]; !ok {
+		log.Println(player, "is revealed to everyone as", character)
+		board.publicRoles[player] = character
+	}
+}
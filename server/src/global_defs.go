@@ -1,5 +1,11 @@
 package main
 
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
 
 const ( //game state
 	NotStarted                          = iota
@@ -52,12 +58,7 @@ var globalConfigPerNumOfPlayers = map[int]boardConfigurations{
 	13: {8, 5, []int{4, 5, 5, 6, 5, 6, 6, 7}, []int{5, 5, 5, 7, 7, 7, 7, 3}},
 }
 
-var neutralCharacters = map[string]bool{
-	"Ginerva": true,
-	"Puck":    true,
-	"Gawain":  true,
-	TheQuestingBeast: true,
-}
+var neutralCharacters = map[string]bool{} // filled by registerCharacter
 
 var optionalGoodsForStray = []string{GoodAngel, Titanya, Nimue, Raven, KingArthur, SirRobin,
 	TheCoward, MerlinApprentice, Guinevere, Gornemant, Blanchefleur, SirGawain, Elaine, LoyalServentOfArthur, LoyalServentOfArthurA, LoyalServentOfArthurB, LoyalServentOfArthurC, LoyalServentOfArthurD}
@@ -111,6 +112,13 @@ const ( // Good Characters...
 	LoyalServentOfArthurB = "Loyal-Servent-Of-Arthur2"
 	LoyalServentOfArthurC = "Loyal-Servent-Of-Arthur3"
 	LoyalServentOfArthurD = "Loyal-Servent-Of-Arthur4"
+	Cleric = "Cleric"
+	UntrustworthyServant = "Untrustworthy-Servant"
+	Troublemaker = "Troublemaker"
+	GoodSorcerer = "Good-Sorcerer"
+	GoodRogue = "Good-Rogue"
+	SeniorMessenger = "Senior-Messenger"
+	JuniorMessenger = "Junior-Messenger"
 )
 
 const (
@@ -140,74 +148,17 @@ const ( // Bad Characters...
 	MinionOfMordred = "Minion-Of-Mordred"
 	MinionOfMordredA = "Minion-Of-Mordred1"
 	MinionOfMordredB = "Minion-Of-Mordred2"
+	Lunatic = "Lunatic"
+	Brute = "Brute"
+	Revealer = "Revealer"
+	Trickster = "Trickster"
+	EvilSorcerer = "Evil-Sorcerer"
+	EvilRogue = "Evil-Rogue"
+	EvilMessenger = "Evil-Messenger"
 )
 
-var goodCharacters = map[string]bool{
-	Viviana:           true,
-	KingArthur:       true,
-	Seer:              true,
-	Titanya:           true,
-	Galahad:           true,
-	Nimue:             true,
-	SirKay:           true,
-	GoodAngel:        true,
-	Percival:          true,
-	Merlin:            true,
-	Tristan:           true,
-	Iseult:            true,
-	PrinceClaudin:    true,
-	Nirlem:            true,
-	SirRobin:         true,
-	Pellinore:         true,
-	Lot:               true,
-	Cordana:           true,
-	TheCoward:        true,
-	MerlinApprentice: true,
-	LancelotGood:     true,
-	Guinevere:         true,
-	Galaad:            true,
-	Raven:             true,
-	Balain:            true,
-	SirGawain:        true,
-	Jarvan:            true,
-	Stray:             true,
-	Ector:             true,
-	Elaine:            true,
-	Blanchefleur:      true,
-	TomThumb:			true,
-	Gornemant:         true,
-	Dagonet:           true,
-	Meliagant:         true,
-	Bors:              true,
-	UtherPendragon:		true,
-	LoyalServentOfArthurA: true,
-	LoyalServentOfArthurB: true,
-	LoyalServentOfArthurC: true,
-	LoyalServentOfArthurD: true,
-	LoyalServentOfArthur: true,
-}
-var badCharacters = map[string]bool{
-	Morgana:            true,
-	Assassin:           true,
-	Mordred:            true,
-	Oberon:             true,
-	BadAngel:          true,
-	KingClaudin:       true,
-	Polygraph:          true,
-	Accolon:            true,
-	LancelotBad:       true,
-	QueenMab:          true,
-	Balin:              true,
-	Maeve:              true,
-	Agravain:           true,
-	Nerzhul:            true,
-	Mora:               true,
-	Melwas:             true,
-	Claudas:			true,
-	MinionOfMordred: 	true,
-	MinionOfMordredA:	true,
-	MinionOfMordredB:	true,
-}
+var goodCharacters = map[string]bool{} // filled by registerCharacter
+var badCharacters = map[string]bool{} // filled by registerCharacter
 
 type PlayerName struct {
 	Player string `json:"player,omitempty"`
@@ -224,6 +175,9 @@ type QuestStats struct {
 	NumOfBeasts   int `json:"beasts,omitempty"`
 	AvalonPower   bool `json:"avalon_power,omitempty"`
 	NumOfEmpty int	`json:"empty,omitempty"`
+	NumOfMagic    int `json:"magic,omitempty"`
+	NumOfRogueSuccess int `json:"rogueSuccesses,omitempty"`
+	NumOfRogueFail    int `json:"rogueFailures,omitempty"`
 }
 
 const ( //Flags
@@ -238,6 +192,8 @@ const ( //Flags
 	LADY
 	BEAST_VOTE_SEEN
 	BEAST_AND_PELLINORE_AT_SAME_QUEST
+	GOOD_ROGUE_CARD_PLAYED
+	EVIL_ROGUE_CARD_PLAYED
 )
 
 type QuestManager struct {
@@ -260,11 +216,17 @@ type QuestArchiveItem struct {
 	SuggestedPlayers               []string   `json:"suggestedPlayers"`
 	IsSuggestionAccepted           bool       `json:"isSuggestionAccepted"`
 	IsSwitchLancelot               bool       `json:"switch"`
+	LancelotVariant                string     `json:"lancelotVariant,omitempty"`
+	LancelotCard                   string     `json:"lancelotCard,omitempty"` // the loyalty card drawn after this quest
+	LancelotDeck                   []string   `json:"lancelotDeck,omitempty"` // the cards left, for variants that reveal the deck
 	NumberOfReversal               int        `json:"numberOfReversal"`
 	NumberOfSuccesses              int        `json:"numberOfSuccesses"`
 	NumberOfFailures               int        `json:"numberOfFailures"`
 	NumberOfBeasts                 int        `json:"numberOfBeasts"`
 	NumberOfEmpty              int        `json:"numberOfEmpty"`
+	NumberOfMagic                  int        `json:"numberOfMagic"`
+	NumberOfRogueSuccesses         int        `json:"numberOfRogueSuccesses"`
+	NumberOfRogueFailures          int        `json:"numberOfRogueFailures"`
 	AvalonPower   					bool `json:"avalon_power,omitempty"`
 	FinalResult                    int        `json:"finalResult"`
 	Id                             float32    `json:"questId"` //e.g. 1.1 , 2 ..
@@ -273,6 +235,8 @@ type QuestArchiveItem struct {
 	LadySuggester                  string     `json:"LadySuggester"`
 	LadyChosenPlayer               string     `json:"LadyChosenPlayer"`
 	LadySuggesterPublishToTheWorld string     `json:"LadySuggesterPublishToTheWorld"`
+	Murders                        []Murder   `json:"murders,omitempty"` // the murders tried after the game was decided
+	PlotEvents                     []PlotEvent `json:"plotEvents,omitempty"` // the plot cards dealt and played in this turn
 }
 
 type QuestSuggestionsManager struct {
@@ -301,19 +265,39 @@ type PlayerSecrets struct {
 	Seen string `json:"Seen"`
 	PlayerSee2  string `json:"PlayersSee2"`
 	Seen2 string `json:"Seen2"`
+	PlotDraw      []string          `json:"plotDraw,omitempty"`      // the plot cards the leader has to deal
+	PlotSeenVotes map[string]string `json:"plotSeenVotes,omitempty"` // player -> the quest card seen with Keeping A Close Eye On You
+	Inventory        map[string]int    `json:"inventory,omitempty"`        // item -> count, e.g. the messenger tokens left
+	MessengerReports map[string]string `json:"messengerReports,omitempty"` // player -> the loyalty a messenger brought back
 }
 
 type BoardGame struct {
+	name                 string
+	mutex                *sync.RWMutex
+	commandMutex         *sync.Mutex // serializes game commands, so a command checked by the state machine runs in that state
+	rng                  *rand.Rand  // the game's only source of randomness, created from Seed
+	Seed                 int64
 	whoSeeWho map[string]map[string]bool
 	clientIdToPlayerName map[string]PlayerName
+	connections          map[string]SeatConnection // player -> seat connection status
 
 	numOfPlayers			int
+	layout               BoardConfiguration // the quests of the game, chosen at start
+	rules                gameRules          // the house rules, chosen at start
+	targeting            bool               // the assassin declares a target before the murder
+	plot                 PlotStats
 	numOfConnectedPlayers	int
 	ladyOfTheLake            LadyStats
 
 	playersWithGoodCharacter []string //for vivian
 	PlayersWithBadCharacter  []string //for vivian
 	playersWithCharacters  map[string]string //for vivian
+	publicRoles            map[string]string // player -> role, shown to everyone
+	convertedPlayers       map[string]string // player -> the loyalty the player changed to
+	individualWins         []IndividualWin   // players that win on their own, announced when the game is over
+	inventory              map[string]map[string]int // player -> item -> count, kept across quests
+	identityChanges        []IdentityChange  // every role change, in order
+	transitionErr          *CommandError     // an undeclared state transition taken by setState, see takeTransitionError
 
 	SecretsMap				map[string]*PlayerSecrets
 
@@ -328,6 +312,7 @@ type BoardGame struct {
 	PlayerToMurderInfo       map[string]MurderInfo
 	quests                   QuestManager
 	archive                  []QuestArchiveItem
+	lancelot                 LancelotVariant
 	lancelotCards            []int
 	lancelotCardsIndex       int
 	suggestions              QuestSuggestionsManager
@@ -335,7 +320,7 @@ type BoardGame struct {
 	isSuggestionPassed       bool
 	isSuggestionGood         int
 	isSuggestionBad          int
-	manager                  ClientManager
+	manager                  *ClientManager
 
 	QuestStage float32 // e.g. 1, 1.1, 1.2 then 2 ..
 	LastQuestStage float32 // e.g. 1, 1.1, 1.2 then 2 .. if quest is canceled
@@ -343,33 +328,39 @@ type BoardGame struct {
 	StateDescription      string
 }
 
-var globalBoard = BoardGame{
-	PlayersWithBadCharacter:  make([]string, 0),
-	playersWithGoodCharacter: make([]string, 0),
-	playersWithCharacters: make(map[string]string),
-	clientIdToPlayerName:     make(map[string]PlayerName),
-	QuestStage:               1,
-	lancelotCards:            make([]int, 7),
-	Secrets:                  make(map[string][]string),
-	SecretsMap: 				make(map[string]*PlayerSecrets),
-	PlayerToMurderInfo:       make(map[string]MurderInfo),
-	quests: QuestManager{
-		current:                    0,
-		playersVotes:               make([][]int, 20),
-		results:                    make(map[int]QuestStats),
-		realResults:                make(map[int]QuestStats),
-		successfulQuest:            0,
-		unsuccessfulQuest:          0,
-		playerVotedForCurrent:      make(map[string]int),
-		playerVotedForCurrentQuest: make([]string, 0),
-		differentResults:           make(map[int]int),
-		Flags:                      make(map[int]bool),
-	},
-	archive: make([]QuestArchiveItem, 0),
-	manager: ClientManager{
-		broadcast:  make(chan []byte),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		clients:    make(map[*Client]bool),
-	},
+// newBoardGame returns an empty board for a room. The lock and the client manager
+// are shared with the caller so a reset keeps the room's connections.
+func newBoardGame(name string, mutex *sync.RWMutex, manager *ClientManager) BoardGame {
+	return BoardGame{
+		name:                     name,
+		mutex:                    mutex,
+		commandMutex:             &sync.Mutex{},
+		rng:                      rand.New(rand.NewSource(time.Now().UnixNano())),
+		manager:                  manager,
+		PlayersWithBadCharacter:  make([]string, 0),
+		playersWithGoodCharacter: make([]string, 0),
+		playersWithCharacters:    make(map[string]string),
+		clientIdToPlayerName:     make(map[string]PlayerName),
+		connections:              make(map[string]SeatConnection),
+		PlayerNames:              make([]PlayerName, 0),
+		QuestStage:               1,
+		lancelotCards:            make([]int, 7),
+		rules:                    defaultRules,
+		Secrets:                  make(map[string][]string),
+		SecretsMap:               make(map[string]*PlayerSecrets),
+		PlayerToMurderInfo:       make(map[string]MurderInfo),
+		quests: QuestManager{
+			current:                    0,
+			playersVotes:               make([][]int, 20),
+			results:                    make(map[int]QuestStats),
+			realResults:                make(map[int]QuestStats),
+			successfulQuest:            0,
+			unsuccessfulQuest:          0,
+			playerVotedForCurrent:      make(map[string]int),
+			playerVotedForCurrentQuest: make([]string, 0),
+			differentResults:           make(map[int]int),
+			Flags:                      make(map[int]bool),
+		},
+		archive: make([]QuestArchiveItem, 0),
+	}
 }
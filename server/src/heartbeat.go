@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// getEnvDuration get key environment variable as a duration (e.g. "30s") if valid otherwise return defaultValue
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, ""))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+var (
+	pingInterval      = getEnvDuration("WS_PING_INTERVAL", 25*time.Second)
+	pongWait          = getEnvDuration("WS_PONG_WAIT", 60*time.Second)
+	writeWait         = getEnvDuration("WS_WRITE_WAIT", 10*time.Second)
+	idleAfter         = getEnvDuration("WS_IDLE_AFTER", 45*time.Second)
+	idleCheckInterval = getEnvDuration("WS_IDLE_CHECK_INTERVAL", 5*time.Second)
+)
+
+// touch records that the client was heard from (message or pong).
+func (c *Client) touch() {
+	atomic.StoreInt64(&c.lastSeen, time.Now().UnixNano())
+}
+
+// LastSeen returns the time the client was last heard from in unix milliseconds.
+func (c *Client) LastSeen() int64 {
+	return atomic.LoadInt64(&c.lastSeen) / int64(time.Millisecond)
+}
+
+// checkIdleClients updates the seats' connection status and tells the table when someone goes
+// silent or comes back. Dead sockets are closed by the read deadline.
+func (manager *ClientManager) checkIdleClients() {
+	now := time.Now()
+	changed := false
+	manager.board.mutex.Lock()
+	for conn := range manager.clients {
+		lastSeen := conn.LastSeen()
+		connection := manager.board.connections[conn.id]
+		isIdle := now.Sub(time.Unix(0, lastSeen*int64(time.Millisecond))) > idleAfter
+		if isIdle && connection.Status != SeatIdle {
+			log.Println(conn.id, "went silent")
+			connection.Status = SeatIdle
+			changed = true
+		} else if !isIdle && connection.Status == SeatIdle {
+			log.Println(conn.id, "is back")
+			connection.Status = SeatConnected
+			changed = true
+		}
+		connection.LastSeen = lastSeen
+		manager.board.connections[conn.id] = connection
+	}
+	manager.board.mutex.Unlock()
+
+	if changed {
+		jsonMessage, _ := json.Marshal(&Message{Content: "board"})
+		manager.handleBroadcast(jsonMessage)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// The test games seat these players, in join order, one per role.
+var testSeats = []string{"alice", "bob", "carol", "dave", "erin", "frank", "grace", "heidi", "ivan", "judy"}
+
+// classicRoles are a five player game with no roles beyond the base game.
+var classicRoles = []string{Merlin, Assassin, Percival, Morgana, LoyalServentOfArthur}
+
+// testSeed keeps the seating and the roles of a test game the same on every run.
+const testSeed = int64(7)
+
+// newTestRoom is an empty room that was never started.
+func newTestRoom() *BoardGame {
+	manager := &ClientManager{
+		clients: make(map[*Client]bool),
+		missed:  make(map[string][]sequencedMessage),
+	}
+	board := newBoardGame("test", &sync.RWMutex{}, manager)
+	manager.board = &board
+	return &board
+}
+
+/*
+seatTestGame seats a player per role in a new room and adds the roles to config. The Assassin murders;
+without one the first bad role does.
+*/
+func seatTestGame(config GameConfiguration, roles ...string) (*BoardGame, GameConfiguration) {
+	board := newTestRoom()
+	assassin := ""
+	for _, role := range roles {
+		if role == Assassin || (assassin == "" && characterRules(role) != nil && characterRules(role).Side == BAD) {
+			assassin = role
+		}
+	}
+	for i, role := range roles {
+		board.PlayerNames = append(board.PlayerNames, PlayerName{testSeats[i]})
+		config.Characters = append(config.Characters, Ch{Name: role, Checked: true, Assassin: role == assassin})
+	}
+	return board, config
+}
+
+// startTestGame starts a seated game through StartGameHandler, with testSeed unless config has a seed.
+func startTestGame(t *testing.T, config GameConfiguration, roles ...string) *BoardGame {
+	t.Helper()
+	board, config := seatTestGame(config, roles...)
+	if config.Seed == nil {
+		seed := testSeed
+		config.Seed = &seed
+	}
+	if err := board.StartGameHandler(config); err != nil {
+		t.Fatal("Game should start, got", err)
+	}
+	checkTransitions(t, board)
+	return board
+}
+
+// checkTransitions fails the test when the board took a transition the state machine does not declare.
+func checkTransitions(t *testing.T, board *BoardGame) {
+	t.Helper()
+	if err := board.takeTransitionError(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// playerOf is the player that holds role.
+func playerOf(board *BoardGame, role string) string {
+	return board.CharacterToPlayer[role].Player
+}
+
+// playersOf are the players that hold roles, in order.
+func playersOf(board *BoardGame, roles ...string) []string {
+	players := make([]string, 0, len(roles))
+	for _, role := range roles {
+		players = append(players, playerOf(board, role))
+	}
+	return players
+}
+
+// otherPlayers are the seats after the leader, in order.
+func otherPlayers(board *BoardGame) []string {
+	players := make([]string, 0)
+	for i := 1; i < len(board.PlayerNames); i++ {
+		players = append(players, board.PlayerNames[(board.suggestions.suggesterIndex+i)%len(board.PlayerNames)].Player)
+	}
+	return players
+}
+
+// questTeam is the first players that fill the team of the current quest.
+func questTeam(board *BoardGame, players ...string) []string {
+	return players[:board.quests.results[board.quests.current+1].NumOfPlayers]
+}
+
+// voteTeam suggests team and has every seated player approve it.
+func voteTeam(t *testing.T, board *BoardGame, team []string) {
+	t.Helper()
+	if err := board.HandleNewSuggest(Suggestion{Players: team}); err != nil {
+		t.Fatal("The suggestion should be accepted, got", err)
+	}
+	for _, player := range board.PlayerNames {
+		if board.State != SuggestionVoting {
+			break
+		}
+		if err := board.HandleSuggestionVote(VoteForSuggestion{PlayerName: player.Player, Vote: true}); err != nil {
+			t.Fatal("The vote should be accepted, got", err)
+		}
+	}
+	if board.State != JorneyVoting {
+		t.Fatal("The team should be approved, got", board.State)
+	}
+	checkTransitions(t, board)
+}
+
+// playQuest approves team and plays the quest. The failers fail it and the rest of the team succeeds.
+func playQuest(t *testing.T, board *BoardGame, team []string, failers ...string) {
+	t.Helper()
+	voteTeam(t, board, team)
+	for _, player := range team {
+		vote := VoteForJourney{PlayerName: player, Vote: VoteSuccess}
+		if SliceIndex(len(failers), func(i int) bool { return failers[i] == player }) != -1 {
+			vote.Vote = VoteFail
+		}
+		if err := board.HandleJourneyVote(vote); err != nil {
+			t.Fatal("The quest vote should be accepted, got", err)
+		}
+	}
+	checkTransitions(t, board)
+}
+
+// winQuests has the goods win quests from players until the game leaves the quests.
+func winQuests(t *testing.T, board *BoardGame, players ...string) {
+	t.Helper()
+	for board.State == WaitingForSuggestion {
+		playQuest(t, board, questTeam(board, players...))
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strconv"
+)
+
+/*
+HouseRules is the house rules section of GameConfiguration. Empty fields keep the rules this server
+always played: the last suggestion of a quest is approved without a vote, the retries come from the
+board, anyone may be sent on a quest and the suggester may hand Excalibur to themselves.
+*/
+type HouseRules struct {
+	HammerApproves       *bool `json:"hammerApproves,omitempty"`       // false: the last suggestion is voted on, and rejecting it wins the game for the bads
+	Retries              int   `json:"retries,omitempty"`              // suggestions per quest, replaces the board's retries
+	SuggesterOnTeam      bool  `json:"suggesterOnTeam,omitempty"`      // the suggester must be on their own team
+	ExcaliburToSuggester *bool `json:"excaliburToSuggester,omitempty"` // false: the suggester may not hold Excalibur
+}
+
+// gameRules is the resolved house rules the handlers read.
+type gameRules struct {
+	HammerApproves       bool `json:"hammerApproves"`
+	SuggesterOnTeam      bool `json:"suggesterOnTeam"`
+	ExcaliburToSuggester bool `json:"excaliburToSuggester"`
+}
+
+var defaultRules = gameRules{HammerApproves: true, ExcaliburToSuggester: true}
+
+// chooseRules resolves the house rules and applies their retries to the layout.
+func chooseRules(requested *HouseRules, layout *BoardConfiguration) (gameRules, error) {
+	rules := defaultRules
+	if requested == nil {
+		return rules, nil
+	}
+	if requested.HammerApproves != nil {
+		rules.HammerApproves = *requested.HammerApproves
+	}
+	if requested.ExcaliburToSuggester != nil {
+		rules.ExcaliburToSuggester = *requested.ExcaliburToSuggester
+	}
+	rules.SuggesterOnTeam = requested.SuggesterOnTeam
+	if requested.Retries < 0 {
+		return rules, newCommandError(ErrInvalidConfig, "house rules: cannot have "+strconv.Itoa(requested.Retries)+" retries")
+	}
+	if requested.Retries > 0 {
+		// the layout may share its slices with a preset
+		layout.RetriesPerLevel = make([]int, layout.NumOfQuests)
+		for i := range layout.RetriesPerLevel {
+			layout.RetriesPerLevel[i] = requested.Retries
+		}
+	}
+	return rules, nil
+}
+
+// retriesFor is the number of suggestions quest level (0 based) allows.
+func (board *BoardGame) retriesFor(level int) int {
+	if level < 0 || level >= len(board.layout.RetriesPerLevel) {
+		return 0
+	}
+	return board.layout.RetriesPerLevel[level]
+}
+
+// isLastRetry tells whether the current suggestion is the last one the current quest allows (the hammer).
+func (board *BoardGame) isLastRetry() bool {
+	return board.suggestions.unsuccessfulRetries == board.retriesFor(board.quests.current)-1
+}
+
+// setVetoPlayer gives the veto to whoever suggests last for quest level (0 based), when firstSuggester starts it.
+func (board *BoardGame) setVetoPlayer(firstSuggester int, level int) {
+	retries := board.retriesFor(level)
+	if retries == 0 {
+		return
+	}
+	suggesterVetoIn := (firstSuggester + retries - 1) % len(board.PlayerNames)
+	board.suggestions.PlayerWithVeto = board.PlayerNames[suggesterVetoIn].Player
+}
+
+// passSuggestion hands the turn to the next leader, who draws the plot cards.
+func (board *BoardGame) passSuggestion() {
+	board.suggestions.suggesterIndex = (board.suggestions.suggesterIndex + 1) % len(board.PlayerNames)
+	board.drawPlotCards()
+}
+
+// validateHouseRules checks the suggestion against the house rules.
+func (board *BoardGame) validateHouseRules(pl Suggestion) error {
+	suggester := board.PlayerNames[board.suggestions.suggesterIndex%len(board.PlayerNames)].Player
+	if board.rules.SuggesterOnTeam {
+		onTeam := false
+		for _, player := range pl.Players {
+			onTeam = onTeam || player == suggester
+		}
+		if !onTeam {
+			return newCommandError(ErrInvalidTeam, "the suggester must be on the team")
+		}
+	}
+	if !board.rules.ExcaliburToSuggester && pl.ExcaliburPlayer == suggester {
+		return newCommandError(ErrInvalidTeam, "the suggester cannot hold excalibur")
+	}
+	return nil
+}
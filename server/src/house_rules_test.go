@@ -0,0 +1,118 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_HouseRules(t *testing.T) {
+	t.Run("Keeps the hammer by default", should_keep_hammer_by_default)
+	t.Run("Lets the bads win when the last suggestion is rejected without a hammer", should_let_bads_win_without_hammer)
+	t.Run("Requires the suggester on the team", should_require_suggester_on_team)
+	t.Run("Keeps Excalibur from the suggester", should_keep_excalibur_from_suggester)
+	t.Run("Replaces the retries without touching the preset", should_replace_retries)
+}
+
+// newHammerTestRoom rejects the first suggestion of a game with two suggestions per quest.
+func newHammerTestRoom(t *testing.T, rules HouseRules) *BoardGame {
+	rules.Retries = 2
+	board := startTestGame(t, GameConfiguration{HouseRules: &rules}, Merlin, Assassin, Percival, Morgana,
+		LoyalServentOfArthur)
+	board.HandleNewSuggest(Suggestion{Players: otherPlayers(board)[:2]})
+	for _, player := range board.PlayerNames {
+		board.HandleSuggestionVote(VoteForSuggestion{PlayerName: player.Player, Vote: false})
+	}
+	if board.State != WaitingForSuggestion {
+		t.Fatal("The first suggestion should be rejected, got", board.State)
+	}
+	return board
+}
+
+func should_keep_hammer_by_default(t *testing.T) {
+	//Arrange
+	board := newHammerTestRoom(t, HouseRules{})
+
+	//Act
+	err := board.HandleNewSuggest(Suggestion{Players: otherPlayers(board)[:2]})
+
+	//Assert
+	if err != nil || board.State != JorneyVoting {
+		t.Error("The last suggestion should be approved, got", err, board.State)
+	}
+	archive := board.GetGameState("alice").Archive
+	if len(archive[len(archive)-1].PlayersVotedYes) != 5 {
+		t.Error("Everyone should be recorded as approving, got", archive[len(archive)-1].PlayersVotedYes)
+	}
+}
+
+func should_let_bads_win_without_hammer(t *testing.T) {
+	//Arrange
+	hammer := false
+	board := newHammerTestRoom(t, HouseRules{HammerApproves: &hammer})
+
+	//Act
+	board.HandleNewSuggest(Suggestion{Players: otherPlayers(board)[:2]})
+	state := board.State
+	for _, player := range board.PlayerNames {
+		board.HandleSuggestionVote(VoteForSuggestion{PlayerName: player.Player, Vote: false})
+	}
+
+	//Assert
+	if state != SuggestionVoting {
+		t.Error("The last suggestion should be voted on, got", state)
+	}
+	if board.State != VictoryForBad && board.State != MurdersAfterBadVictory {
+		t.Error("Rejecting the last suggestion should win for the bads, got", board.State)
+	}
+}
+
+func should_require_suggester_on_team(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{HouseRules: &HouseRules{SuggesterOnTeam: true}}, Merlin, Assassin,
+		Percival, Morgana, LoyalServentOfArthur)
+	leader, others := board.leader(), otherPlayers(board)
+
+	//Act
+	without := board.HandleNewSuggest(Suggestion{Players: others[:2]})
+	with := board.HandleNewSuggest(Suggestion{Players: []string{leader, others[0]}})
+
+	//Assert
+	if cerr, ok := without.(*CommandError); !ok || cerr.Code != ErrInvalidTeam {
+		t.Error("Expected invalid_team, got", without)
+	}
+	if with != nil {
+		t.Error("A team with the suggester should be accepted, got", with)
+	}
+}
+
+func should_keep_excalibur_from_suggester(t *testing.T) {
+	//Arrange
+	toSuggester := false
+	board := startTestGame(t, GameConfiguration{Excalibur: true, HouseRules: &HouseRules{ExcaliburToSuggester: &toSuggester}},
+		Merlin, Assassin, Percival, Morgana, LoyalServentOfArthur)
+	leader := board.leader()
+
+	//Act
+	err := board.HandleNewSuggest(Suggestion{Players: []string{leader, otherPlayers(board)[0]}, ExcaliburPlayer: leader})
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidTeam {
+		t.Error("Expected invalid_team, got", err)
+	}
+}
+
+func should_replace_retries(t *testing.T) {
+	//Arrange
+	layout, _ := chooseLayout(&BoardConfiguration{Preset: "avalon-5"}, 5, 5)
+	hammer := false
+
+	//Act
+	rules, err := chooseRules(&HouseRules{HammerApproves: &hammer, Retries: 3}, &layout)
+
+	//Assert
+	if err != nil || rules.HammerApproves || !rules.ExcaliburToSuggester {
+		t.Error("The rules should be resolved, got", rules, err)
+	}
+	if layout.RetriesPerLevel[4] != 3 || boardPresets["avalon-5"].RetriesPerLevel[4] != 5 {
+		t.Error("Only the game's retries should change, got", layout.RetriesPerLevel, boardPresets["avalon-5"].RetriesPerLevel)
+	}
+}
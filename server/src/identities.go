@@ -0,0 +1,110 @@
+package main
+
+import "log"
+
+// IdentityChange is a player taking another role during the game. Quest is 0 before the first quest.
+type IdentityChange struct {
+	Player string `json:"player"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Quest  int    `json:"quest"`
+	Reason string `json:"reason"`
+}
+
+const (
+	IdentityStray    = "stray"
+	IdentityLancelot = "lancelot"
+	IdentityBalin    = "balin"
+)
+
+// identityKeepsRole are the roles that stay in the game after their player takes another one.
+// The Stray is still found by its own name at night and in the role descriptions.
+var identityKeepsRole = map[string]bool{Stray: true}
+
+type identitySwap struct {
+	player PlayerName
+	to     string
+}
+
+// changeIdentities hands the players their new roles all at once, records every change and fixes the
+// state derived from the roles: the assassin, the role list and descriptions and what was uncovered to players.
+func (board *BoardGame) changeIdentities(reason string, quest int, swaps ...identitySwap) {
+	assassinPlayer, hasAssassin := board.CharacterToPlayer[Assassin]
+	assassinRole := board.PlayerToCharacter[assassinPlayer]
+
+	changes := make([]IdentityChange, 0, len(swaps))
+	for _, swap := range swaps {
+		from := board.PlayerToCharacter[swap.player]
+		changes = append(changes, IdentityChange{Player: swap.player.Player, From: from, To: swap.to, Quest: quest, Reason: reason})
+		if holder, ok := board.CharacterToPlayer[from]; ok && holder == swap.player && !identityKeepsRole[from] {
+			delete(board.CharacterToPlayer, from)
+		}
+	}
+	for _, swap := range swaps {
+		board.PlayerToCharacter[swap.player] = swap.to
+		board.CharacterToPlayer[swap.to] = swap.player
+	}
+	board.identityChanges = append(board.identityChanges, changes...)
+	log.Println("identity changes:", changes)
+
+	// The assassin is whoever holds the role that was picked to kill, when that role is not the Assassin itself.
+	if holder, ok := board.CharacterToPlayer[assassinRole]; hasAssassin && assassinRole != Assassin && ok {
+		board.CharacterToPlayer[Assassin] = holder
+	}
+	if board.State == NotStarted {
+		return // the rest is built when the game starts
+	}
+	for _, change := range changes {
+		if !identityKeepsRole[change.From] {
+			if i := SliceIndex(len(board.Characters), func(i int) bool { return board.Characters[i] == change.From }); i >= 0 {
+				board.Characters[i] = change.To
+			}
+		}
+		board.fixUncoveredIdentity(change)
+	}
+	if board.suggestions.SuggestedCharacters != nil {
+		board.suggestions.SuggestedCharacters = make(map[string]bool)
+		for _, player := range board.suggestions.SuggestedPlayers {
+			board.suggestions.SuggestedCharacters[board.PlayerToCharacter[PlayerName{player}]] = true
+		}
+	}
+	board.describeRoles()
+}
+
+// fixUncoveredIdentity updates what the players learned about a player that changed roles.
+func (board *BoardGame) fixUncoveredIdentity(change IdentityChange) {
+	for _, secrets := range board.SecretsMap {
+		if secrets != nil && secrets.PlayersWithUncoveredCharacters[change.Player] == change.From {
+			secrets.PlayersWithUncoveredCharacters[change.Player] = change.To
+		}
+	}
+	if board.playersWithCharacters[change.Player] == change.From {
+		board.playersWithCharacters[change.Player] = change.To
+	}
+
+	// Viviana learned the loyalty of the player, which may have changed with the role.
+	from, to := characterRules(change.From), characterRules(change.To)
+	if from == nil || to == nil || from.Loyalty == to.Loyalty {
+		return
+	}
+	moveLoyalty := func(goods *[]string, bads *[]string) {
+		if to.Loyalty == GOOD {
+			moveIdentity(bads, goods, change.Player)
+		} else if to.Loyalty == BAD {
+			moveIdentity(goods, bads, change.Player)
+		}
+	}
+	moveLoyalty(&board.playersWithGoodCharacter, &board.PlayersWithBadCharacter)
+	if vivianaPlayer, ok := board.CharacterToPlayer[Viviana]; ok && board.SecretsMap[vivianaPlayer.Player] != nil {
+		secrets := board.SecretsMap[vivianaPlayer.Player]
+		moveLoyalty(&secrets.PlayersWithGoodCharacter, &secrets.PlayersWithBadCharacter)
+	}
+}
+
+// moveIdentity moves player from one list to the other, when it is there.
+func moveIdentity(from *[]string, to *[]string, player string) {
+	if i := SliceIndex(len(*from), func(i int) bool { return (*from)[i] == player }); i >= 0 {
+		*from = append((*from)[:i], (*from)[i+1:]...)
+		*to = append(*to, player)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_Identities(t *testing.T) {
+	t.Run("Records a swap and moves the assassin with the role", should_move_assassin_with_role)
+	t.Run("Replaces the role of a lone Lancelot", should_replace_lone_lancelot_role)
+	t.Run("Swaps Balin and Balain on the same team", should_swap_balin_and_balain)
+	t.Run("Moves the loyalty Viviana learned", should_move_viviana_loyalty)
+	t.Run("Shows the role history once the game is over", should_show_role_history_after_game)
+}
+
+// newIdentitiesRoom starts a game whose first Lancelot card switches. Lancelot-Bad is the assassin.
+func newIdentitiesRoom(t *testing.T, variant string, roles ...string) *BoardGame {
+	board := startTestGame(t, GameConfiguration{Lancelot: variant}, roles...)
+	board.lancelotCards = []int{1, 0, 0, 0, 0, 0, 0}
+	return board
+}
+
+// leadBy rejects suggestions until player leads.
+func leadBy(t *testing.T, board *BoardGame, player string) {
+	for board.leader() != player {
+		board.HandleNewSuggest(Suggestion{Players: questTeam(board, otherPlayers(board)...)})
+		for _, voter := range board.PlayerNames {
+			board.HandleSuggestionVote(VoteForSuggestion{PlayerName: voter.Player, Vote: false})
+		}
+		if board.State != WaitingForSuggestion {
+			t.Fatal("The suggestion should be rejected, got", board.State)
+		}
+	}
+}
+
+func should_move_assassin_with_role(t *testing.T) {
+	//Arrange
+	board := newIdentitiesRoom(t, "", Merlin, LancelotBad, LancelotGood, Morgana, Viviana)
+	badLancelot, goodLancelot := playerOf(board, LancelotBad), playerOf(board, LancelotGood)
+
+	//Act
+	playQuest(t, board, playersOf(board, Merlin, Viviana))
+
+	//Assert
+	if playerOf(board, Assassin) != goodLancelot || playerOf(board, LancelotBad) != goodLancelot {
+		t.Error("The assassin should follow Lancelot-Bad, got", board.CharacterToPlayer)
+	}
+	if !sameStringSlice(board.Characters, []string{Merlin, LancelotBad, LancelotGood, Morgana, Viviana}) {
+		t.Error("A swap should keep the roles in the game, got", board.Characters)
+	}
+	if len(board.identityChanges) != 2 || board.identityChanges[0] != (IdentityChange{Player: badLancelot, From: LancelotBad,
+		To: LancelotGood, Quest: 1, Reason: IdentityLancelot}) {
+		t.Error("Both changes should be recorded, got", board.identityChanges)
+	}
+	if board.OtherRolesDescriptions[LancelotBad].Loyalty != BAD {
+		t.Error("The role descriptions should be rebuilt, got", board.OtherRolesDescriptions)
+	}
+}
+
+func should_replace_lone_lancelot_role(t *testing.T) {
+	//Arrange
+	board := newIdentitiesRoom(t, "", Merlin, LancelotBad, LoyalServentOfArthur, Morgana, Viviana)
+	lancelot := playerOf(board, LancelotBad)
+
+	//Act
+	playQuest(t, board, playersOf(board, Merlin, Viviana))
+
+	//Assert
+	if _, ok := board.CharacterToPlayer[LancelotBad]; ok || board.PlayerToCharacter[PlayerName{lancelot}] != LancelotGood {
+		t.Error("The only Lancelot should now be good, got", board.CharacterToPlayer)
+	}
+	if !sameStringSlice(board.Characters, []string{Merlin, LancelotGood, LoyalServentOfArthur, Morgana, Viviana}) {
+		t.Error("Lancelot-Bad should leave the game, got", board.Characters)
+	}
+	archive := board.GetGameState("alice").Archive
+	if !board.quests.Flags[HAS_ONLY_GOOD_LANCELOT] || !archive[len(archive)-1].IsSwitchLancelot {
+		t.Error("The switch should be recorded, got", board.quests.Flags, archive[len(archive)-1])
+	}
+}
+
+func should_swap_balin_and_balain(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Balin, Balain, Morgana, Viviana)
+	balin, balain := playerOf(board, Balin), playerOf(board, Balain)
+
+	//Act
+	voteTeam(t, board, []string{balin, balain})
+
+	//Assert
+	if board.PlayerToCharacter[PlayerName{balin}] != Balain || board.PlayerToCharacter[PlayerName{balain}] != Balin {
+		t.Error("Balin and Balain should swap, got", board.PlayerToCharacter)
+	}
+	if playerOf(board, Assassin) != balain {
+		t.Error("The assassin should follow Balin, got", board.CharacterToPlayer[Assassin])
+	}
+	if len(board.identityChanges) != 2 || board.identityChanges[1].Reason != IdentityBalin || board.identityChanges[1].Quest != 1 {
+		t.Error("The swap should be recorded on quest 1, got", board.identityChanges)
+	}
+}
+
+func should_move_viviana_loyalty(t *testing.T) {
+	//Arrange
+	board := newIdentitiesRoom(t, "3", Merlin, LancelotBad, LancelotGood, Morgana, Viviana)
+	badLancelot, goodLancelot := playerOf(board, LancelotBad), playerOf(board, LancelotGood)
+	goods := playersOf(board, Merlin, Viviana)
+	leadBy(t, board, badLancelot)
+	playQuest(t, board, goods)
+
+	//Act
+	playQuest(t, board, append(goods, goodLancelot))
+
+	//Assert
+	viviana := board.SecretsMap[playerOf(board, Viviana)]
+	isBad := SliceIndex(len(viviana.PlayersWithBadCharacter), func(i int) bool { return viviana.PlayersWithBadCharacter[i] == badLancelot })
+	isGood := SliceIndex(len(viviana.PlayersWithGoodCharacter), func(i int) bool { return viviana.PlayersWithGoodCharacter[i] == badLancelot })
+	if isBad != -1 || isGood == -1 {
+		t.Error("Viviana should now see the former Lancelot-Bad as good, got", viviana)
+	}
+	if uncovered := board.SecretsMap[goodLancelot].PlayersWithUncoveredCharacters[badLancelot]; uncovered != LancelotGood {
+		t.Error("The other Lancelot should see the new role, got", uncovered)
+	}
+}
+
+func should_show_role_history_after_game(t *testing.T) {
+	//Arrange
+	board := newIdentitiesRoom(t, "", Merlin, LancelotBad, LancelotGood, Morgana, Viviana)
+	goodLancelot, morgana := playerOf(board, LancelotGood), playerOf(board, Morgana)
+	playQuest(t, board, playersOf(board, Merlin, Viviana))
+
+	//Act
+	during := board.GetGameState("alice").RoleHistory
+	for board.State == WaitingForSuggestion {
+		playQuest(t, board, questTeam(board, morgana, playerOf(board, Merlin), playerOf(board, Viviana)), morgana)
+	}
+	after := board.GetGameState("alice").RoleHistory
+
+	//Assert
+	if during != nil {
+		t.Error("The history should stay hidden during the game, got", during)
+	}
+	if !board.isGameOver() || len(after) != 2 || after[1].Player != goodLancelot || after[1].To != LancelotBad {
+		t.Error("The history should be shown once the game is over, got", board.State, after)
+	}
+}
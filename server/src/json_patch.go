@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PatchOperation is one RFC 6902 JSON Patch operation.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON always writes "value" for add and replace, even when it is null.
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	if op.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{op.Op, op.Path})
+	}
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{op.Op, op.Path, op.Value})
+}
+
+// diffJSON returns the operations that turn from into to. Both are generic JSON values as
+// produced by json.Unmarshal into an interface{}.
+func diffJSON(from, to interface{}) []PatchOperation {
+	return appendDiff(make([]PatchOperation, 0), "", from, to)
+}
+
+func appendDiff(ops []PatchOperation, path string, from, to interface{}) []PatchOperation {
+	switch toValue := to.(type) {
+	case map[string]interface{}:
+		fromValue, ok := from.(map[string]interface{})
+		if !ok {
+			return append(ops, PatchOperation{Op: "replace", Path: path, Value: to})
+		}
+		keys := make([]string, 0, len(fromValue)+len(toValue))
+		for k := range fromValue {
+			keys = append(keys, k)
+		}
+		for k := range toValue {
+			if _, ok := fromValue[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			childPath := path + "/" + escapePointer(k)
+			oldChild, inFrom := fromValue[k]
+			newChild, inTo := toValue[k]
+			if !inTo {
+				ops = append(ops, PatchOperation{Op: "remove", Path: childPath})
+			} else if !inFrom {
+				ops = append(ops, PatchOperation{Op: "add", Path: childPath, Value: newChild})
+			} else {
+				ops = appendDiff(ops, childPath, oldChild, newChild)
+			}
+		}
+		return ops
+	case []interface{}:
+		fromValue, ok := from.([]interface{})
+		if !ok {
+			return append(ops, PatchOperation{Op: "replace", Path: path, Value: to})
+		}
+		common := len(fromValue)
+		if len(toValue) < common {
+			common = len(toValue)
+		}
+		for i := 0; i < common; i++ {
+			ops = appendDiff(ops, path+"/"+strconv.Itoa(i), fromValue[i], toValue[i])
+		}
+		for i := len(fromValue) - 1; i >= common; i-- {
+			ops = append(ops, PatchOperation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+		}
+		for i := common; i < len(toValue); i++ {
+			ops = append(ops, PatchOperation{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: toValue[i]})
+		}
+		return ops
+	default:
+		if !reflect.DeepEqual(from, to) {
+			ops = append(ops, PatchOperation{Op: "replace", Path: path, Value: to})
+		}
+		return ops
+	}
+}
+
+// escapePointer escapes a key for a JSON Pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_JSONPatch(t *testing.T) {
+	t.Run("Patch turns the old state into the new one", should_patch_old_state_into_new_state)
+	t.Run("No operations for equal states", should_return_no_operations_for_equal_states)
+	t.Run("Patches are taken against the acknowledged state", should_diff_against_acknowledged_state)
+}
+
+func decodeJSON(s string) interface{} {
+	var v interface{}
+	json.Unmarshal([]byte(s), &v)
+	return v
+}
+
+// applyTestPatch is a minimal RFC 6902 add/remove/replace implementation to check diffJSON.
+func applyTestPatch(doc interface{}, ops []PatchOperation) interface{} {
+	for _, op := range ops {
+		if op.Path == "" {
+			doc = op.Value
+			continue
+		}
+		parts := strings.Split(op.Path[1:], "/")
+		doc = applyTestOperation(doc, parts, op)
+	}
+	return doc
+}
+
+func applyTestOperation(node interface{}, parts []string, op PatchOperation) interface{} {
+	key := strings.ReplaceAll(strings.ReplaceAll(parts[0], "~1", "/"), "~0", "~")
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if len(parts) > 1 {
+			n[key] = applyTestOperation(n[key], parts[1:], op)
+		} else if op.Op == "remove" {
+			delete(n, key)
+		} else {
+			n[key] = op.Value
+		}
+		return n
+	case []interface{}:
+		i, _ := strconv.Atoi(key)
+		if len(parts) > 1 {
+			n[i] = applyTestOperation(n[i], parts[1:], op)
+			return n
+		}
+		switch op.Op {
+		case "remove":
+			return append(n[:i], n[i+1:]...)
+		case "add":
+			return append(n[:i], append([]interface{}{op.Value}, n[i:]...)...)
+		default:
+			n[i] = op.Value
+			return n
+		}
+	}
+	return node
+}
+
+func should_patch_old_state_into_new_state(t *testing.T) {
+	//Arrange
+	from := decodeJSON(`{"state":2,"archive":[{"id":1},{"id":1.1}],"players":{"all":["a","b"]},"a/b":1,"gone":true}`)
+	to := decodeJSON(`{"state":3,"archive":[{"id":1,"yes":["a"]}],"players":{"all":["a","b","c"]},"a/b":null,"new":{"x":1}}`)
+
+	//Act
+	ops := diffJSON(from, to)
+	patched := applyTestPatch(decodeJSON(`{"state":2,"archive":[{"id":1},{"id":1.1}],"players":{"all":["a","b"]},"a/b":1,"gone":true}`), ops)
+
+	//Assert
+	if !reflect.DeepEqual(patched, to) {
+		t.Error("Patched state differs. ops:", ops, "patched:", patched)
+	}
+	encoded, _ := json.Marshal(ops)
+	if !strings.Contains(string(encoded), `{"op":"replace","path":"/a~1b","value":null}`) {
+		t.Error("Replace with null should keep the value field:", string(encoded))
+	}
+}
+
+func should_return_no_operations_for_equal_states(t *testing.T) {
+	ops := diffJSON(decodeJSON(`{"a":[1,2,{"b":"c"}]}`), decodeJSON(`{"a":[1,2,{"b":"c"}]}`))
+
+	if len(ops) != 0 {
+		t.Error("Expected no operations, got", ops)
+	}
+}
+
+func should_diff_against_acknowledged_state(t *testing.T) {
+	//Arrange
+	p := newPatchState(true)
+
+	//Act
+	first := p.update("", 1, decodeJSON(`{"state":1}`))
+	second := p.update("", 2, decodeJSON(`{"state":2}`))
+	p.ack(1)
+	third := p.update("", 3, decodeJSON(`{"state":3}`))
+	p.reset()
+	fourth := p.update("", 4, decodeJSON(`{"state":4}`))
+
+	//Assert
+	if first.Type != "snapshot" || second.Type != "snapshot" {
+		t.Error("Updates before the first ack should be snapshots")
+	}
+	if third.Type != "patch" || third.Base != 1 {
+		t.Error("Update after ack should be a patch against the acknowledged state, got", third)
+	}
+	if fourth.Type != "snapshot" {
+		t.Error("Update after resync should be a snapshot")
+	}
+}
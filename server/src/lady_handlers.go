@@ -1,111 +1,177 @@
 package main
 
-import "log"
-
-type LadySuggestMessage struct {
-	Tp      string `json:"type"`
-	Content string `json:"content"`
-}
-
-type LadyResponseMessage struct {
-	Tp      string `json:"type"`
-	Content int    `json:"content"`
-}
-
-type LadyPublishResponseMessage struct {
-	Tp      string `json:"type"`
-	Content int    `json:"content"`
-}
+import (
+	"log"
+	"strconv"
+)
 
 type LadyStats struct {
 	currentSuggester    string
 	currentChosenPlayer string
 	previousSuggester string
 	ladyResponse        int
+	options             LadyConfiguration
+	holders             map[string]bool // everyone who held the Lady, they cannot be targeted
+	history             []LadyLedgerEntry
 }
 
-func getOptionalLoyalty(player string) []string {
-	character := globalBoard.PlayerToCharacter[PlayerName{player}]
-	if character == QueenMab {
-		return []string{"Bad", "Good"}
-	}
+/*
+LadyConfiguration is the Lady Of The Lake section of GameConfiguration.
+  firstHolder   the player that starts with the Lady. the last seat when empty
+  quests        the quests (1 based) after which the Lady is used. every quest from the 2nd when empty
+  publicResult  the chosen player's answer is shown to everyone instead of being published by the holder
+*/
+type LadyConfiguration struct {
+	FirstHolder  string `json:"firstHolder,omitempty"`
+	Quests       []int  `json:"quests,omitempty"`
+	PublicResult bool   `json:"publicResult,omitempty"`
+}
 
-	if character == Lot {
-		return []string{"Bad"}
-	}
+// LadyLedgerEntry is one use of the Lady. Answer is only filled when the result is public.
+type LadyLedgerEntry struct {
+	Quest     int    `json:"quest"` // the Lady was used after this quest
+	Holder    string `json:"holder"`
+	Target    string `json:"target"`
+	Answer    string `json:"answer,omitempty"`
+	Published string `json:"published,omitempty"`
+}
 
-	if character == Meliagant {
-		return []string{"Bad"}
+func (board *BoardGame) validateLadyOptions(options *LadyConfiguration, numOfQuests int) error {
+	if options == nil {
+		return nil
 	}
-
-	if character == "Gawain" || character == "Ginerva" {
-		return []string{"Bad"}
+	if options.FirstHolder != "" && !board.isSeated(options.FirstHolder) {
+		return newCommandError(ErrInvalidConfig, "lady: "+options.FirstHolder+" is not seated")
 	}
+	for _, quest := range options.Quests {
+		if quest < 1 || quest >= numOfQuests {
+			return newCommandError(ErrInvalidConfig, "lady: cannot be used after quest "+strconv.Itoa(quest))
+		}
+	}
+	return nil
+}
 
-	if character == "Puck" {
-		return []string{"Good"}
+// startLady gives the Lady to its first holder. The players are seated already.
+func (board *BoardGame) startLady(options *LadyConfiguration) {
+	board.ladyOfTheLake.options = LadyConfiguration{}
+	if options != nil {
+		board.ladyOfTheLake.options = *options
 	}
+	holder := board.ladyOfTheLake.options.FirstHolder
+	if holder == "" {
+		holder = board.PlayerNames[len(board.PlayerNames)-1].Player
+	}
+	board.ladyOfTheLake.currentSuggester = holder
+	board.ladyOfTheLake.holders = map[string]bool{holder: true}
+	board.ladyOfTheLake.history = make([]LadyLedgerEntry, 0)
+}
 
-	if character == Raven {
-		return []string{"Bad"}
+// isLadyQuest tells whether the Lady is used after quest (0 based).
+func (board *BoardGame) isLadyQuest(quest int) bool {
+	if !board.quests.Flags[LADY] {
+		return false
+	}
+	if len(board.ladyOfTheLake.options.Quests) == 0 {
+		return quest >= 1
+	}
+	for _, q := range board.ladyOfTheLake.options.Quests {
+		if q == quest+1 {
+			return true
+		}
 	}
+	return false
+}
 
-	if _, ok := badCharacters[character]; ok {
-		return []string{"Bad"}
+func getLoyaltyStr(loyalty int) string {
+	if loyalty == 1 {
+		return GOOD
 	}
+	return BAD
+}
+
+// passLady ends the current use of the Lady. The chosen player holds it next.
+func (board *BoardGame) passLady() {
+	board.setState(WaitingForSuggestion)
+	board.ladyOfTheLake.previousSuggester = board.ladyOfTheLake.currentSuggester
+	board.ladyOfTheLake.currentSuggester = board.ladyOfTheLake.currentChosenPlayer
+	board.ladyOfTheLake.holders[board.ladyOfTheLake.currentSuggester] = true
+	board.ladyOfTheLake.currentChosenPlayer = ""
+	board.ladyOfTheLake.ladyResponse = -1
+	suggesterIndex := board.suggestions.suggesterIndex
+	board.StateDescription = "Suggestion For Next Quest: " + board.PlayerNames[suggesterIndex].Player +
+		" is choosing players..."
+}
 
-	if _, ok := goodCharacters[character]; ok {
-		return []string{"Good"}
+func (board *BoardGame) getOptionalLoyalty(player string) []string {
+	if rules := characterRules(board.PlayerToCharacter[PlayerName{player}]); rules != nil {
+		return append([]string{}, rules.LadyAnswers...)
 	}
+	return []string{NEUTRAL}
+}
 
-	return []string{"Neutral"}
+// inspectedLoyalty is what a loyalty check other than the Lady finds, following the InspectedAnswer of the role.
+func (board *BoardGame) inspectedLoyalty(player string) string {
+	if rules := characterRules(board.PlayerToCharacter[PlayerName{player}]); rules != nil {
+		return rules.InspectedAnswer
+	}
+	return NEUTRAL
 }
 
-func LadySuggestHandler(suggestion string) {
+func (board *BoardGame) LadySuggestHandler(suggestion string) error {
 	log.Println("got lady suggestion:", suggestion)
-	globalMutex.Lock()
-	curEntry := globalBoard.archive[len(globalBoard.archive)-1] //Stats table
+	board.mutex.Lock()
+	if err := board.validateLadyTarget(suggestion); err != nil {
+		board.mutex.Unlock()
+		return err
+	}
+	curEntry := board.archive[len(board.archive)-1] //Stats table
 	curEntry.LadyChosenPlayer = suggestion
-	curEntry.LadySuggester = globalBoard.ladyOfTheLake.currentSuggester
-	globalBoard.archive[len(globalBoard.archive)-1] = curEntry
-	globalBoard.ladyOfTheLake.currentChosenPlayer = suggestion
-
-	globalBoard.State = LadyResponse
-	globalBoard.StateDescription = "Lady Of The Lake: " + suggestion + " got The Lady. Waiting for his answer..."
-	globalMutex.Unlock()
+	curEntry.LadySuggester = board.ladyOfTheLake.currentSuggester
+	board.archive[len(board.archive)-1] = curEntry
+	board.ladyOfTheLake.currentChosenPlayer = suggestion
+	board.ladyOfTheLake.history = append(board.ladyOfTheLake.history, LadyLedgerEntry{Quest: board.quests.current,
+		Holder: board.ladyOfTheLake.currentSuggester, Target: suggestion})
+
+	board.setState(LadyResponse)
+	board.StateDescription = "Lady Of The Lake: " + suggestion + " got The Lady. Waiting for his answer..."
+	board.mutex.Unlock()
+	return nil
 }
 
-func LadyResponseHandler(loyalty int) {
+func (board *BoardGame) LadyResponseHandler(loyalty int) error {
 	log.Println("got lady response:", loyalty)
-	globalMutex.Lock()
-	globalBoard.State = LadySuggesterPublishResponseToWorld
-	globalBoard.ladyOfTheLake.ladyResponse = loyalty
-	globalBoard.StateDescription = "Lady Of The Lake: " + globalBoard.ladyOfTheLake.currentSuggester + " got response from " + globalBoard.ladyOfTheLake.currentChosenPlayer +". Waiting for his publication..."
-	globalMutex.Unlock()
-}
-
-func LadyPublishResponseHandler(loyalty int) {
-	log.Println("got lady publish response:", loyalty)
-	globalMutex.Lock()
-	defer globalMutex.Unlock()
-
-	if globalBoard.State != LadySuggesterPublishResponseToWorld {
-		return
+	board.mutex.Lock()
+	if err := board.validateLadyAnswer(loyalty); err != nil {
+		board.mutex.Unlock()
+		return err
 	}
-
-	curEntry := globalBoard.archive[len(globalBoard.archive)-1] //Stats table
-	if loyalty == 1 {
-		curEntry.LadySuggesterPublishToTheWorld = "Good"
-	} else {
-		curEntry.LadySuggesterPublishToTheWorld = "Bad"
+	board.ladyOfTheLake.ladyResponse = loyalty
+	if board.ladyOfTheLake.options.PublicResult {
+		answer := getLoyaltyStr(loyalty)
+		board.ladyOfTheLake.history[len(board.ladyOfTheLake.history)-1].Answer = answer
+		curEntry := board.archive[len(board.archive)-1] //Stats table
+		curEntry.LadySuggesterPublishToTheWorld = answer
+		board.archive[len(board.archive)-1] = curEntry
+		board.passLady()
+		board.mutex.Unlock()
+		return nil
 	}
+	board.setState(LadySuggesterPublishResponseToWorld)
+	board.StateDescription = "Lady Of The Lake: " + board.ladyOfTheLake.currentSuggester + " got response from " + board.ladyOfTheLake.currentChosenPlayer +". Waiting for his publication..."
+	board.mutex.Unlock()
+	return nil
+}
 
-	globalBoard.archive[len(globalBoard.archive)-1] = curEntry
+func (board *BoardGame) LadyPublishResponseHandler(loyalty int) error {
+	log.Println("got lady publish response:", loyalty)
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
 
-	globalBoard.State = WaitingForSuggestion
-	globalBoard.ladyOfTheLake.previousSuggester = globalBoard.ladyOfTheLake.currentSuggester
-	globalBoard.ladyOfTheLake.currentSuggester = globalBoard.ladyOfTheLake.currentChosenPlayer
-	globalBoard.ladyOfTheLake.currentChosenPlayer = ""
-	globalBoard.ladyOfTheLake.ladyResponse = -1
+	curEntry := board.archive[len(board.archive)-1] //Stats table
+	curEntry.LadySuggesterPublishToTheWorld = getLoyaltyStr(loyalty)
+	board.archive[len(board.archive)-1] = curEntry
+	board.ladyOfTheLake.history[len(board.ladyOfTheLake.history)-1].Published = getLoyaltyStr(loyalty)
 
+	board.passLady()
+	return nil
 }
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_Lady(t *testing.T) {
+	t.Run("Keeps a ledger of holders, targets and answers", should_keep_lady_ledger)
+	t.Run("Rejects former holders as targets", should_reject_former_lady_holders)
+	t.Run("Shows the answer to everyone in the public variant", should_show_public_lady_result)
+	t.Run("Rejects an answer the chosen player may not give", should_reject_lady_answer_not_offered)
+	t.Run("Uses the Lady only after the configured quests", should_use_lady_after_configured_quests)
+}
+
+// newLadyTestRoom has the goods win the first two quests, after which the Lady is used. classicRoles play without roles.
+func newLadyTestRoom(t *testing.T, options *LadyConfiguration, roles ...string) *BoardGame {
+	if len(roles) == 0 {
+		roles = classicRoles
+	}
+	board := startTestGame(t, GameConfiguration{Lady: true, LadyOptions: options}, roles...)
+	goods := make([]string, 0)
+	for _, role := range roles {
+		if characterRules(role).Side == GOOD {
+			goods = append(goods, playerOf(board, role))
+		}
+	}
+	for i := 0; i < 2; i++ {
+		playQuest(t, board, questTeam(board, goods...))
+	}
+	if board.State != WaitingForLadySuggester {
+		t.Fatal("The Lady should be used after quest 2, got", board.State)
+	}
+	return board
+}
+
+// ladyAnswer is the answer player gives the Lady, the first one the role offers.
+func ladyAnswer(board *BoardGame, player string) int {
+	if board.getOptionalLoyalty(player)[0] == GOOD {
+		return 1
+	}
+	return 0
+}
+
+func should_keep_lady_ledger(t *testing.T) {
+	//Arrange
+	board := newLadyTestRoom(t, &LadyConfiguration{FirstHolder: "alice"})
+
+	//Act
+	board.LadySuggestHandler("bob")
+	board.LadyResponseHandler(ladyAnswer(board, "bob"))
+	board.LadyPublishResponseHandler(1)
+
+	//Assert
+	history := board.GetGameState("carol").LadyHistory
+	if len(history) != 1 || history[0] != (LadyLedgerEntry{Quest: 2, Holder: "alice", Target: "bob", Published: GOOD}) {
+		t.Error("The ledger should record the use of the Lady without the private answer, got", history)
+	}
+	if board.ladyOfTheLake.currentSuggester != "bob" || board.State != WaitingForSuggestion {
+		t.Error("bob should hold the Lady, got", board.ladyOfTheLake.currentSuggester, board.State)
+	}
+}
+
+func should_reject_former_lady_holders(t *testing.T) {
+	//Arrange
+	board := newLadyTestRoom(t, &LadyConfiguration{FirstHolder: "alice", Quests: []int{2, 3}})
+	board.LadySuggestHandler("bob")
+	board.LadyResponseHandler(ladyAnswer(board, "bob"))
+	board.LadyPublishResponseHandler(0)
+	assassin := playerOf(board, Assassin)
+	playQuest(t, board, questTeam(board, assassin, playerOf(board, Merlin), playerOf(board, Percival)), assassin)
+
+	//Act
+	err := board.LadySuggestHandler("alice")
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidTarget {
+		t.Error("Expected invalid_target, got", err)
+	}
+	if board.State != WaitingForLadySuggester {
+		t.Error("State should not change, got", board.State)
+	}
+}
+
+func should_show_public_lady_result(t *testing.T) {
+	//Arrange
+	board := newLadyTestRoom(t, &LadyConfiguration{PublicResult: true})
+	holder := board.PlayerNames[len(board.PlayerNames)-1].Player
+	target := board.PlayerNames[0].Player
+	answer := ladyAnswer(board, target)
+
+	//Act
+	board.LadySuggestHandler(target)
+	board.LadyResponseHandler(answer)
+
+	//Assert
+	history := board.GetGameState(target).LadyHistory
+	if len(history) != 1 || history[0].Holder != holder || history[0].Answer != getLoyaltyStr(answer) {
+		t.Error("The answer should be public, got", history)
+	}
+	if board.State != WaitingForSuggestion || board.ladyOfTheLake.currentSuggester != target {
+		t.Error("The Lady should pass without publishing, got", board.State, board.ladyOfTheLake.currentSuggester)
+	}
+}
+
+func should_reject_lady_answer_not_offered(t *testing.T) {
+	//Arrange
+	board := newLadyTestRoom(t, &LadyConfiguration{PublicResult: true})
+	merlin := playerOf(board, Merlin)
+	if err := board.LadySuggestHandler(merlin); err != nil {
+		t.Fatal("The Lady should go to Merlin, got", err)
+	}
+
+	//Act
+	err := board.LadyResponseHandler(0)
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidVote {
+		t.Error("Expected invalid_vote, got", err)
+	}
+	if history := board.ladyOfTheLake.history; history[len(history)-1].Answer != "" {
+		t.Error("Nothing should be shown, got", history)
+	}
+	if board.State != LadyResponse {
+		t.Error("State should not change, got", board.State)
+	}
+}
+
+func should_use_lady_after_configured_quests(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{Lady: true, LadyOptions: &LadyConfiguration{Quests: []int{3}}},
+		Merlin, Assassin, Percival, Morgana, LoyalServentOfArthur)
+
+	//Act
+	afterSecond, afterThird := board.isLadyQuest(1), board.isLadyQuest(2)
+
+	//Assert
+	if afterSecond || !afterThird {
+		t.Error("The Lady should be used only after quest 3, got", afterSecond, afterThird)
+	}
+	if err := board.validateLadyOptions(&LadyConfiguration{FirstHolder: "mallory"}, 5); err == nil {
+		t.Error("A first holder that is not seated should be rejected")
+	}
+}
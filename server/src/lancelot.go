@@ -0,0 +1,139 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+/*
+LancelotVariant is how the two Lancelots change sides. The loyalty deck is shuffled at the start and
+one card is drawn after each quest, for the quests from FirstDrawQuest on.
+Revealed variants show the whole deck to the table, and in KnowEachOther variants the Lancelots see
+each other at night.
+*/
+type LancelotVariant struct {
+	Name           string `json:"name"`
+	Switches       int    `json:"switches"`  // switch cards in the deck
+	NoChanges      int    `json:"noChanges"` // blank cards in the deck
+	FirstDrawQuest int    `json:"firstDrawQuest"`
+	Revealed       bool   `json:"revealed,omitempty"`
+	KnowEachOther  bool   `json:"knowEachOther,omitempty"`
+}
+
+const (
+	LancelotSwitch   = "Switch"
+	LancelotNoChange = "No-Change"
+)
+
+// lancelotVariants are the house rules this server always played and the rulebook variants.
+var lancelotVariants = map[string]LancelotVariant{
+	"house": {Name: "house", Switches: 2, NoChanges: 5, FirstDrawQuest: 2},
+	"1":     {Name: "1", Switches: 2, NoChanges: 5, FirstDrawQuest: 3},
+	"2":     {Name: "2", Switches: 2, NoChanges: 3, FirstDrawQuest: 3, Revealed: true},
+	"3":     {Name: "3", Switches: 2, NoChanges: 5, FirstDrawQuest: 3, KnowEachOther: true},
+}
+
+func chooseLancelotVariant(name string) (LancelotVariant, error) {
+	if name == "" {
+		name = "house"
+	}
+	variant, ok := lancelotVariants[name]
+	if !ok {
+		names := make([]string, 0, len(lancelotVariants))
+		for n := range lancelotVariants {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return variant, newCommandError(ErrInvalidConfig, "unknown lancelot variant "+name+", expected one of "+
+			strings.Join(names, ", "))
+	}
+	return variant, nil
+}
+
+// shuffleLancelotCards deals the variant's loyalty deck. 1 is a switch card.
+func (board *BoardGame) shuffleLancelotCards() {
+	board.lancelotCards = make([]int, 0, board.lancelot.Switches+board.lancelot.NoChanges)
+	for i := 0; i < board.lancelot.NoChanges; i++ {
+		board.lancelotCards = append(board.lancelotCards, 0)
+	}
+	for i := 0; i < board.lancelot.Switches; i++ {
+		board.lancelotCards = append(board.lancelotCards, 1)
+	}
+	board.rng.Shuffle(len(board.lancelotCards), func(i, j int) {
+		board.lancelotCards[i], board.lancelotCards[j] = board.lancelotCards[j], board.lancelotCards[i]
+	})
+	board.lancelotCardsIndex = 0
+	log.Println("lancelot variant", board.lancelot.Name, "deck", board.lancelotCards)
+}
+
+func getLancelotCardStr(card int) string {
+	if card == 1 {
+		return LancelotSwitch
+	}
+	return LancelotNoChange
+}
+
+// lancelotDeckView is the rest of the deck, in draw order, when the variant reveals it.
+func (board *BoardGame) lancelotDeckView() []string {
+	if !board.lancelot.Revealed || len(board.lancelotCards) == 0 {
+		return nil
+	}
+	deck := make([]string, 0, len(board.lancelotCards))
+	for i := range board.lancelotCards {
+		deck = append(deck, getLancelotCardStr(board.lancelotCards[(board.lancelotCardsIndex+i)%len(board.lancelotCards)]))
+	}
+	return deck
+}
+
+func (board *BoardGame) hasLancelot() bool {
+	return board.quests.Flags[HAS_TWO_LANCELOT] || board.quests.Flags[HAS_ONLY_BAD_LANCELOT] ||
+		board.quests.Flags[HAS_ONLY_GOOD_LANCELOT]
+}
+
+// drawLancelotCard draws the loyalty card after quest current (0 based) and switches the Lancelots on a switch card.
+func (board *BoardGame) drawLancelotCard(curEntry *QuestArchiveItem, current int) {
+	if !board.hasLancelot() || len(board.lancelotCards) == 0 {
+		return
+	}
+	curEntry.LancelotVariant = board.lancelot.Name
+	if current+2 < board.lancelot.FirstDrawQuest {
+		curEntry.LancelotDeck = board.lancelotDeckView()
+		return
+	}
+	isSwitchLancelots := board.lancelotCards[board.lancelotCardsIndex]
+	board.lancelotCardsIndex = (board.lancelotCardsIndex + 1) % len(board.lancelotCards)
+	curEntry.LancelotCard = getLancelotCardStr(isSwitchLancelots)
+	curEntry.LancelotDeck = board.lancelotDeckView()
+	if isSwitchLancelots == 1 {
+		lanBad, hasBad := board.CharacterToPlayer[LancelotBad]
+		lanGood, hasGood := board.CharacterToPlayer[LancelotGood]
+		swaps := make([]identitySwap, 0, 2)
+		if hasBad {
+			swaps = append(swaps, identitySwap{lanBad, LancelotGood})
+		}
+		if hasGood {
+			swaps = append(swaps, identitySwap{lanGood, LancelotBad})
+		}
+		board.changeIdentities(IdentityLancelot, current+1, swaps...)
+		curEntry.IsSwitchLancelot = true
+		if board.quests.Flags[HAS_ONLY_BAD_LANCELOT] {
+			delete(board.quests.Flags, HAS_ONLY_BAD_LANCELOT)
+			board.quests.Flags[HAS_ONLY_GOOD_LANCELOT] = true
+		} else if board.quests.Flags[HAS_ONLY_GOOD_LANCELOT] {
+			delete(board.quests.Flags, HAS_ONLY_GOOD_LANCELOT)
+			board.quests.Flags[HAS_ONLY_BAD_LANCELOT] = true
+		}
+	}
+}
+
+// lancelotsNight shows each Lancelot the other one.
+func lancelotsNight(n *night) {
+	other := map[string]string{LancelotGood: LancelotBad, LancelotBad: LancelotGood}[n.character]
+	if other == "" {
+		return
+	}
+	if v, ok := n.board.CharacterToPlayer[other]; ok {
+		n.uncover(v.Player, other)
+	}
+}
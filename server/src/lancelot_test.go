@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_Lancelot(t *testing.T) {
+	t.Run("Rejects an unknown variant", should_reject_unknown_lancelot_variant)
+	t.Run("Draws from quest 3 and records the draws", should_draw_from_quest_three)
+	t.Run("Reveals the deck in variant 2", should_reveal_deck_in_variant_two)
+	t.Run("Lets the Lancelots know each other in variant 3", should_let_lancelots_know_each_other)
+}
+
+func newLancelotGame(t *testing.T, variant string) *BoardGame {
+	return startTestGame(t, GameConfiguration{Lancelot: variant}, Merlin, Percival, LancelotGood,
+		LoyalServentOfArthur, Assassin, Morgana, LancelotBad)
+}
+
+// playLancelotQuests has the goods that are not Lancelots win the first quests.
+func playLancelotQuests(t *testing.T, board *BoardGame, quests int) {
+	goods := []string{playerOf(board, Merlin), playerOf(board, Percival), playerOf(board, LoyalServentOfArthur)}
+	for i := 0; i < quests; i++ {
+		playQuest(t, board, questTeam(board, goods...))
+	}
+}
+
+func should_reject_unknown_lancelot_variant(t *testing.T) {
+	//Act
+	_, err := chooseLancelotVariant("4")
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidConfig {
+		t.Error("Expected invalid_config, got", err)
+	}
+}
+
+func should_draw_from_quest_three(t *testing.T) {
+	//Arrange
+	board := newLancelotGame(t, "1")
+	board.lancelotCards = []int{1, 0, 0, 0, 0, 0, 1}
+	lancelotGood := board.CharacterToPlayer[LancelotGood]
+
+	//Act
+	playLancelotQuests(t, board, 2)
+
+	//Assert
+	archive := board.GetGameState("alice").Archive
+	first, second := archive[0], archive[len(archive)-1]
+	if first.LancelotVariant != "1" || first.LancelotCard != "" || first.IsSwitchLancelot {
+		t.Error("No card should be drawn before quest 3, got", first)
+	}
+	if second.LancelotCard != LancelotSwitch || !second.IsSwitchLancelot || second.LancelotDeck != nil {
+		t.Error("A hidden switch card should be drawn before quest 3, got", second)
+	}
+	if board.PlayerToCharacter[lancelotGood] != LancelotBad || board.State != WaitingForSuggestion {
+		t.Error("The Lancelots should switch, got", board.PlayerToCharacter[lancelotGood], board.State)
+	}
+}
+
+func should_reveal_deck_in_variant_two(t *testing.T) {
+	//Arrange
+	board := newLancelotGame(t, "2")
+	before := board.GetGameState("alice").LancelotDeck
+
+	//Act
+	playLancelotQuests(t, board, 2)
+
+	//Assert
+	archive := board.GetGameState("alice").Archive
+	entry := archive[len(archive)-1]
+	if len(before) != 5 {
+		t.Fatal("Variant 2 should show its 5 cards, got", before)
+	}
+	if entry.LancelotCard != before[0] || len(entry.LancelotDeck) != 5 || entry.LancelotDeck[4] != before[0] {
+		t.Error("The drawn card should be the top of the revealed deck, got", entry.LancelotCard, entry.LancelotDeck, before)
+	}
+}
+
+func should_let_lancelots_know_each_other(t *testing.T) {
+	//Arrange
+	hidden := newLancelotGame(t, "")
+	known := newLancelotGame(t, "3")
+
+	//Act
+	badLancelot := known.CharacterToPlayer[LancelotBad].Player
+	goodLancelot := known.CharacterToPlayer[LancelotGood].Player
+
+	//Assert
+	if known.SecretsMap[goodLancelot].PlayersWithUncoveredCharacters[badLancelot] != LancelotBad ||
+		known.SecretsMap[badLancelot].PlayersWithUncoveredCharacters[goodLancelot] != LancelotGood {
+		t.Error("The Lancelots should see each other, got", known.Secrets[goodLancelot], known.Secrets[badLancelot])
+	}
+	if _, ok := hidden.SecretsMap[hidden.CharacterToPlayer[LancelotGood].Player].PlayersWithUncoveredCharacters[hidden.CharacterToPlayer[LancelotBad].Player]; ok {
+		t.Error("Without variant 3 the Lancelots should not see each other")
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"sync"
+	"strconv"
 	"time"
 )
 
@@ -29,11 +29,10 @@ var (
 	mongoPort          = getEnv("MONGO_PORT", "27017")
 	dbName             = getEnv("MONGO_DB_NAME", "test_db")
 	userCollectionName = getEnv("MONGO_USER_NAME", "user")
+	customRolesPath    = getEnv("AVALON_ROLES_FILE", "")
 
 )
 
-var globalMutex sync.RWMutex
-
 func wsPage(res http.ResponseWriter, req *http.Request) {
 	jwtToken := req.URL.Query().Get("token")
 	log.Println(jwtToken)
@@ -63,13 +62,31 @@ func wsPage(res http.ResponseWriter, req *http.Request) {
 	}
 
 	//uuid,_:= uuid.NewV4()
-	client := &Client{id: userName, socket: conn, send: make(chan []byte)}
-	globalBoard.manager.register <- client
+	client := &Client{id: userName, socket: conn, send: newSendQueue(sendQueueLimit),
+		patch: newPatchState(req.URL.Query().Get("protocol") == ProtocolPatch)}
+	client.touch()
+	client.lastSeq, _ = strconv.ParseInt(req.URL.Query().Get("last_seq"), 10, 64)
 
 	log.Println("new socket. start client read and write threads")
-	go client.read()
 	go client.write()
 
+	roomName := req.URL.Query().Get("room")
+	if roomName == "" {
+		if room, ok := lobby.FindSeat(userName); ok {
+			roomName = room.name
+			log.Println(userName, "has a seat in room", roomName, ". resuming")
+		} else {
+			roomName = defaultRoomName
+		}
+	}
+	if room, err := lobby.GetOrCreateRoom(roomName); err == nil {
+		client.joinRoom(room)
+	} else {
+		log.Println("could not join room", roomName, ":", err)
+	}
+
+	go client.read()
+
 }
 
 type userRouter struct {
@@ -78,6 +95,12 @@ type userRouter struct {
 
 func main() {
 	fmt.Printf("Starting server at http://%s:%s...\n", mongoUrl, mongoPort)
+	if customRolesPath != "" {
+		if err := loadCustomRoles(customRolesPath); err != nil {
+			log.Fatal("custom roles: ", err)
+		}
+		fmt.Println("Loaded custom roles from", customRolesPath)
+	}
 	f, _ := os.OpenFile("testlogfile.log", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
 	defer f.Close()
 	log.SetOutput(f)
@@ -91,9 +114,12 @@ func main() {
 	userService := NewUserService(session.Copy(), dbName, userCollectionName, &hash)
 	userRouter := userRouter{userService}
 
-	go globalBoard.manager.start()
+	lobby.GetOrCreateRoom(defaultRoomName)
 	router := mux.NewRouter()
 	router.HandleFunc("/ws", wsPage).Methods("GET")
+	router.HandleFunc("/rooms", roomsPage).Methods("GET")
+	router.HandleFunc("/state-machine", stateMachinePage).Methods("GET")
+	router.HandleFunc("/boards", boardsPage).Methods("GET")
 
 	router.HandleFunc("/register2", userRouter.createUserHandler).Methods("PUT", "OPTIONS", "POST")
 	router.HandleFunc("/login", userRouter.login).Methods("POST", "OPTIONS")
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"log"
+	"strconv"
+)
+
+const MessengerToken = "Messenger-Token"
+
+// messengerTokens are the tokens each Messenger starts with.
+var messengerTokens = map[string]int{SeniorMessenger: 2, JuniorMessenger: 1, EvilMessenger: 1}
+
+type MessengerAction struct {
+	PlayerName string `json:"playerName"`
+	Target     string `json:"target"`
+}
+
+// The Messengers know each other, without knowing which of them is evil.
+func messengerNight(n *night) {
+	for character := range messengerTokens {
+		if v, ok := n.board.CharacterToPlayer[character]; ok && character != n.character {
+			n.reveal(v.Player, v.Player+" is a Messenger", "Messenger")
+		}
+	}
+}
+
+// startMessengers hands the Messengers their tokens.
+func (board *BoardGame) startMessengers() {
+	for character, tokens := range messengerTokens {
+		if v, ok := board.CharacterToPlayer[character]; ok {
+			board.giveItem(v.Player, MessengerToken, tokens)
+		}
+	}
+}
+
+// giveItem adds to the inventory of a player, which lasts for the whole game.
+func (board *BoardGame) giveItem(player string, item string, count int) {
+	if board.inventory == nil {
+		board.inventory = make(map[string]map[string]int)
+	}
+	if board.inventory[player] == nil {
+		board.inventory[player] = make(map[string]int)
+	}
+	board.inventory[player][item] += count
+	board.showInventory(player)
+}
+
+// spendItem takes one item from the inventory of a player, and tells whether the player had it.
+func (board *BoardGame) spendItem(player string, item string) bool {
+	if board.inventory[player][item] == 0 {
+		return false
+	}
+	board.inventory[player][item]--
+	board.showInventory(player)
+	return true
+}
+
+func (board *BoardGame) showInventory(player string) {
+	secrets := board.SecretsMap[player]
+	if secrets == nil {
+		return
+	}
+	secrets.Inventory = make(map[string]int)
+	for item, count := range board.inventory[player] {
+		if count > 0 {
+			secrets.Inventory[item] = count
+		}
+	}
+}
+
+// MessengerActionHandler spends a token of a Messenger to learn the loyalty of another player.
+func (board *BoardGame) MessengerActionHandler(action MessengerAction) error {
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
+
+	if _, isMessenger := messengerTokens[board.PlayerToCharacter[PlayerName{action.PlayerName}]]; !isMessenger {
+		return newCommandError(ErrInvalidTarget, action.PlayerName+" is not a messenger")
+	}
+	if action.Target == action.PlayerName || !board.isSeated(action.Target) {
+		return newCommandError(ErrInvalidTarget, "cannot send a messenger to "+action.Target)
+	}
+	if !board.spendItem(action.PlayerName, MessengerToken) {
+		return newCommandError(ErrInvalidTarget, action.PlayerName+" has no messenger token left")
+	}
+	loyalty := board.inspectedLoyalty(action.Target)
+	log.Println(action.PlayerName, "sent a messenger to", action.Target, ":", loyalty)
+	board.Secrets[action.PlayerName] = append(board.Secrets[action.PlayerName], action.Target+" is "+loyalty+" (messenger)")
+	if secrets := board.SecretsMap[action.PlayerName]; secrets != nil {
+		if secrets.MessengerReports == nil {
+			secrets.MessengerReports = make(map[string]string)
+		}
+		secrets.MessengerReports[action.Target] = loyalty
+	}
+	board.StateDescription = "Messenger: " + action.PlayerName + " sent a messenger (" +
+		strconv.Itoa(board.inventory[action.PlayerName][MessengerToken]) + " left)"
+	return nil
+}
+
+// applyEvilMessenger gives an Evil Messenger that kept a token a try at Merlin after the assassin.
+func (board *BoardGame) applyEvilMessenger(murders []Murder) []Murder {
+	evilMessenger, hasEvilMessenger := board.CharacterToPlayer[EvilMessenger]
+	merlin, hasMerlin := board.CharacterToPlayer[Merlin]
+	if !hasEvilMessenger || !hasMerlin || board.inventory[evilMessenger.Player][MessengerToken] == 0 {
+		return murders
+	}
+	return append(murders, Murder{target: []string{merlin.Player}, TargetCharacters: []string{Merlin},
+		By: evilMessenger.Player, ByCharacter: EvilMessenger, StateAfterSuccess: VictoryForBad})
+}
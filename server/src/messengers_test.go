@@ -0,0 +1,130 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_Messengers(t *testing.T) {
+	t.Run("Gives the messengers their tokens", should_give_messenger_tokens)
+	t.Run("Spends a token to learn a loyalty", should_spend_token_for_loyalty)
+	t.Run("Rejects a messenger without tokens", should_reject_messenger_without_tokens)
+	t.Run("Lets a saving Evil Messenger go after Merlin", should_add_evil_messenger_murder)
+	t.Run("Skips an Evil Messenger that spent the token", should_skip_spent_evil_messenger)
+	t.Run("Ends the game when the Assassin finds Merlin first", should_end_game_when_assassin_finds_merlin)
+}
+
+func newMessengersRoom(t *testing.T) *BoardGame {
+	return startTestGame(t, GameConfiguration{}, Merlin, Assassin, SeniorMessenger, EvilMessenger, JuniorMessenger)
+}
+
+// winMessengersQuests has the goods win the quests, so the murders start.
+func winMessengersQuests(t *testing.T, board *BoardGame) {
+	winQuests(t, board, playersOf(board, Merlin, SeniorMessenger, JuniorMessenger)...)
+	if board.State != MurdersAfterGoodVictory {
+		t.Fatal("The goods should win the quests, got", board.State)
+	}
+}
+
+func should_give_messenger_tokens(t *testing.T) {
+	//Arrange
+	board := newMessengersRoom(t)
+
+	//Act
+	senior := board.SecretsMap[playerOf(board, SeniorMessenger)].Inventory
+	junior := board.SecretsMap[playerOf(board, JuniorMessenger)].Inventory
+	evil := board.SecretsMap[playerOf(board, EvilMessenger)].Inventory
+
+	//Assert
+	if senior[MessengerToken] != 2 || junior[MessengerToken] != 1 || evil[MessengerToken] != 1 {
+		t.Error("Expected 2, 1 and 1 tokens, got", senior, junior, evil)
+	}
+	if merlin := board.SecretsMap[playerOf(board, Merlin)].Inventory; merlin != nil {
+		t.Error("Merlin should have nothing, got", merlin)
+	}
+}
+
+func should_spend_token_for_loyalty(t *testing.T) {
+	//Arrange
+	board := newMessengersRoom(t)
+	senior, assassin := playerOf(board, SeniorMessenger), playerOf(board, Assassin)
+
+	//Act
+	err := board.MessengerActionHandler(MessengerAction{PlayerName: senior, Target: assassin})
+
+	//Assert
+	if err != nil {
+		t.Fatal("The Senior Messenger should be able to send a messenger, got", err)
+	}
+	if report := board.SecretsMap[senior].MessengerReports[assassin]; report != BAD {
+		t.Error("Expected the assassin to be reported as bad, got", report)
+	}
+	if tokens := board.SecretsMap[senior].Inventory[MessengerToken]; tokens != 1 {
+		t.Error("Expected a token left, got", tokens)
+	}
+}
+
+func should_reject_messenger_without_tokens(t *testing.T) {
+	//Arrange
+	board := newMessengersRoom(t)
+	junior, assassin := playerOf(board, JuniorMessenger), playerOf(board, Assassin)
+	board.MessengerActionHandler(MessengerAction{PlayerName: junior, Target: playerOf(board, Merlin)})
+
+	//Act
+	err := board.MessengerActionHandler(MessengerAction{PlayerName: junior, Target: assassin})
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidTarget {
+		t.Error("Expected invalid_target, got", err)
+	}
+	if _, ok := board.SecretsMap[junior].MessengerReports[assassin]; ok {
+		t.Error("Nothing should be reported on the assassin")
+	}
+}
+
+func should_add_evil_messenger_murder(t *testing.T) {
+	//Arrange
+	board := newMessengersRoom(t)
+
+	//Act
+	winMessengersQuests(t, board)
+
+	//Assert
+	murders := board.PendingMurders
+	last := murders[len(murders)-1]
+	if last.ByCharacter != EvilMessenger || !sameStringSlice(last.target, []string{playerOf(board, Merlin)}) {
+		t.Error("The Evil Messenger should go after Merlin last, got", murders)
+	}
+}
+
+func should_skip_spent_evil_messenger(t *testing.T) {
+	//Arrange
+	board := newMessengersRoom(t)
+	board.MessengerActionHandler(MessengerAction{PlayerName: playerOf(board, EvilMessenger), Target: playerOf(board, SeniorMessenger)})
+
+	//Act
+	winMessengersQuests(t, board)
+
+	//Assert
+	for _, murder := range board.PendingMurders {
+		if murder.ByCharacter == EvilMessenger {
+			t.Error("The Evil Messenger spent the token, got", board.PendingMurders)
+		}
+	}
+}
+
+func should_end_game_when_assassin_finds_merlin(t *testing.T) {
+	//Arrange
+	board := newMessengersRoom(t)
+	winMessengersQuests(t, board)
+
+	//Act
+	board.HandleMurder(MurderMessageInternal{CharacterKill: Merlin, Rest: []PlayerNameMurder{{Player: playerOf(board, Merlin), Ch: true}}})
+
+	//Assert
+	if board.State != VictoryForBad || board.StateDescription != "VICTORY for Bads" {
+		t.Error("The bads should win, got", board.State, board.StateDescription)
+	}
+	if len(board.PendingMurders) != 0 {
+		t.Error("The Evil Messenger should not try anymore, got", board.PendingMurders)
+	}
+}
@@ -12,12 +12,6 @@ type PlayerNameMurder struct {
 	CharacterToKill string `json:"characterToKill,omitempty"`
 }
 
-
-type MurderMessage struct {
-	Tp      string                `json:"type"`
-	Content MurderMessageInternal `json:"content"`
-}
-
 type MurderMessageInternal struct {
 	CharacterKill string             `json:"assassinkill"`
 	Rest          []PlayerNameMurder `json:"rest"`
@@ -42,54 +36,83 @@ type Murder struct {
 	ByCharacter       string   `json:"byCharacter"`
 	stopIfSucceeded   bool
 	StateAfterSuccess int
+	Declared          string   `json:"declared,omitempty"` // the role the assassin goes after
+	Chosen            []string `json:"chosen,omitempty"`   // the players named, once the murder is done
+	Outcome           string   `json:"outcome,omitempty"`  // MurderSucceeded or MurderFailed, once the murder is done
 }
 
-func HandleMurder(m MurderMessageInternal) {
+func (board *BoardGame) HandleMurder(m MurderMessageInternal) error {
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
+
 	var curMurder Murder
 	selection := m.Rest
 	characterToKill := m.CharacterKill
 	log.Println("selection: ", selection)
-	if globalBoard.State == MurdersAfterGoodVictory {
-		curMurder = globalBoard.PendingMurders[0]
-	} else if globalBoard.State == MurdersAfterBadVictory {
-		curMurder = globalBoard.PendingMurders[0]
+	curMurder = board.PendingMurders[0]
+	if curMurder.ByCharacter == Assassin {
+		if board.targeting {
+			if curMurder.Declared == "" {
+				return newCommandError(ErrInvalidTarget, "the assassin has to declare a target first")
+			}
+			characterToKill = curMurder.Declared
+		} else {
+			curMurder.Declared = characterToKill
+		}
 	}
 
 	chosenPlayers := make([]string, 0)
 	for _, player := range selection {
 		if player.Ch {
-			if globalBoard.PlayerToCharacter[PlayerName{player.Player}] == SirGawain {
-				globalBoard.State = VictoryForSirGawain
-				globalBoard.StateDescription = "VICTORY for SirGawain"
-				return
+			if board.PlayerToCharacter[PlayerName{player.Player}] == SirGawain {
+				board.setState(VictoryForSirGawain)
+				board.StateDescription = "VICTORY for SirGawain"
+				return nil
 			}
 			chosenPlayers = append(chosenPlayers, player.Player)
-			murderInfo, ok := globalBoard.PlayerToMurderInfo[player.Player]
-			if ok {
-				murderInfo.by = append(murderInfo.by, curMurder.By)
-				globalBoard.PlayerToMurderInfo[player.Player] = murderInfo
-			} else {
-				globalBoard.PlayerToMurderInfo[player.Player] = MurderInfo{by: []string{curMurder.By}}
-			}
 		}
 	}
 
-	globalBoard.PendingMurders = globalBoard.PendingMurders[1:]
+	// A role may change when the Assassin names it, e.g. the Untrustworthy Servant turns bad. Nobody was
+	// murdered then and the Assassin tries again.
+	if curMurder.ByCharacter == Assassin && len(chosenPlayers) == 1 {
+		chosen := PlayerName{chosenPlayers[0]}
+		if rules := characterRules(board.PlayerToCharacter[chosen]); rules != nil && rules.Assassinated != nil &&
+			rules.Assassinated(board, chosen) {
+			board.recordMurder(curMurder, chosenPlayers, MurderConverted)
+			board.PendingMurders[0] = curMurder
+			board.StateDescription = "Murder: " + chosen.Player + " is now bad. " + Assassin + " is trying again to kill: " +
+				strings.Join(curMurder.TargetCharacters, ",")
+			return nil
+		}
+	}
+
+	for _, player := range chosenPlayers {
+		murderInfo, ok := board.PlayerToMurderInfo[player]
+		if ok {
+			murderInfo.by = append(murderInfo.by, curMurder.By)
+			board.PlayerToMurderInfo[player] = murderInfo
+		} else {
+			board.PlayerToMurderInfo[player] = MurderInfo{by: []string{curMurder.By}}
+		}
+	}
+
+	board.PendingMurders = board.PendingMurders[1:]
 	murderResult := MurderResult{targetCharacter: curMurder.TargetCharacters, byCharacter: curMurder.ByCharacter}
 
 	var isSuccess bool
 	if curMurder.ByCharacter == Assassin {
 		if len(chosenPlayers) == 1 {
-			if characterToKill == globalBoard.PlayerToCharacter[PlayerName{chosenPlayers[0]}] {
+			if characterToKill == board.PlayerToCharacter[PlayerName{chosenPlayers[0]}] {
 				isSuccess = true
 				log.Println("assassin murder success. chosenPlayers ", chosenPlayers[0])
 			} else {
-				log.Println("assassin murder failed. chosen Player is ", chosenPlayers[0], " with role ", globalBoard.PlayerToCharacter[PlayerName{chosenPlayers[0]}], "instead of ", characterToKill)
+				log.Println("assassin murder failed. chosen Player is ", chosenPlayers[0], " with role ", board.PlayerToCharacter[PlayerName{chosenPlayers[0]}], "instead of ", characterToKill)
 			}
 		}
-		if len(chosenPlayers) == 2 && characterToKill == "The-Lovers"{
-			tristan, _ := globalBoard.CharacterToPlayer[Tristan]
-			iseult, _ := globalBoard.CharacterToPlayer[Iseult]
+		if len(chosenPlayers) == 2 && characterToKill == TheLovers {
+			tristan, _ := board.CharacterToPlayer[Tristan]
+			iseult, _ := board.CharacterToPlayer[Iseult]
 			theLoversSlice := []string{tristan.Player, iseult.Player}
 			if sameStringSlice(chosenPlayers, theLoversSlice) {
 				isSuccess = true
@@ -99,6 +122,11 @@ func HandleMurder(m MurderMessageInternal) {
 	} else {
 		isSuccess = sameStringSlice(curMurder.target, chosenPlayers)
 	}
+	outcome := MurderFailed
+	if isSuccess {
+		outcome = MurderSucceeded
+	}
+	board.recordMurder(curMurder, chosenPlayers, outcome)
 
 	if isSuccess {
 		//murder succeeded!
@@ -107,143 +135,173 @@ func HandleMurder(m MurderMessageInternal) {
 		murderResult.byCharacter = curMurder.ByCharacter
 		murderResult.target = chosenPlayers
 		if curMurder.StateAfterSuccess != 0 {
-			oldState := globalBoard.State
-			globalBoard.State = curMurder.StateAfterSuccess
-			log.Println("New State:", globalBoard.State)
-			if oldState == MurdersAfterBadVictory && globalBoard.State == MurdersAfterGoodVictory {
-				pendingMurders, hasMurders := GetMurdersAfterGoodsWins()
+			oldState := board.State
+			board.setState(curMurder.StateAfterSuccess)
+			log.Println("New State:", board.State)
+			if oldState == MurdersAfterBadVictory && board.State == MurdersAfterGoodVictory {
+				pendingMurders, hasMurders := board.GetMurdersAfterGoodsWins()
 				if !hasMurders {
-					globalBoard.State = VictoryForGood
-					globalBoard.StateDescription = "VICTORY for Goods"
-					globalBoard.PendingMurders = make([]Murder, 0)
-					return
+					board.setState(VictoryForGood)
+					board.StateDescription = "VICTORY for Goods"
+					board.PendingMurders = make([]Murder, 0)
+					return nil
 				} else {
-					globalBoard.PendingMurders = pendingMurders
+					board.PendingMurders = pendingMurders
 				}
 			}
+			if board.isGameOver() {
+				// The murder decided the game, the murders after it are not tried.
+				board.PendingMurders = make([]Murder, 0)
+			}
 		}
 	} else {
 		murderResult.success = false
 	}
 
-	if len(globalBoard.PendingMurders) == 0 {
+	if len(board.PendingMurders) == 0 {
 		log.Println("No more murders")
-		if globalBoard.State == MurdersAfterGoodVictory {
-			globalBoard.State = VictoryForGood
-			globalBoard.StateDescription = "VICTORY for Goods"
-		} else if globalBoard.State == MurdersAfterBadVictory {
-			globalBoard.State = VictoryForBad
-			globalBoard.StateDescription = "VICTORY for Bads"
+		if board.State == MurdersAfterGoodVictory {
+			board.setState(VictoryForGood)
+		} else if board.State == MurdersAfterBadVictory {
+			board.setState(VictoryForBad)
+		}
+		if board.State == VictoryForGood {
+			board.StateDescription = "VICTORY for Goods"
+		} else if board.State == VictoryForBad {
+			board.StateDescription = "VICTORY for Bads"
 		}
 	} else {
-		targetCharactersString := strings.Join(globalBoard.PendingMurders[0].TargetCharacters[:], ",")
-		globalBoard.StateDescription = "Murder: " + globalBoard.PendingMurders[0].ByCharacter + " is trying to kill: " +
+		targetCharactersString := strings.Join(board.PendingMurders[0].TargetCharacters[:], ",")
+		board.StateDescription = "Murder: " + board.PendingMurders[0].ByCharacter + " is trying to kill: " +
 			targetCharactersString
 	}
+	return nil
+}
+
+func (board *BoardGame) GetMurdersAfterGoodsWins() ([]Murder, bool) {
+	murders, _ := board.collectMurders(true)
+	murders = board.applyEvilMessenger(murders)
+	return murders, len(murders) > 0
 }
 
-func GetMurdersAfterGoodsWins() ([]Murder, bool) {
+func (board *BoardGame) GetMurdersAfterBadsWins() ([]Murder, bool) {
+	return board.collectMurders(false)
+}
 
+// collectMurders asks every role in the registry, in registry order, for the murders it tries.
+func (board *BoardGame) collectMurders(goodsWon bool) ([]Murder, bool) {
 	murders := make([]Murder, 0)
+	for _, rules := range characterRegistry {
+		if rules.Murders != nil {
+			murders = append(murders, rules.Murders(board, goodsWon)...)
+		}
+	}
+	return murders, len(murders) > 0
+}
 
-	if beast, isTheQuestingBeastExists := isCharacterExists(true, TheQuestingBeast); isTheQuestingBeastExists {
-		if pellinore, isPellinoreExists := isCharacterExists(true, Pellinore); isPellinoreExists {
+// Pellinore hunts the Beast, unless the Beast was seen or they went on a quest together.
+func pellinoreMurders(board *BoardGame, goodsWon bool) []Murder {
+	beast, isTheQuestingBeastExists := board.isCharacterExists(true, TheQuestingBeast)
+	pellinore, isPellinoreExists := board.isCharacterExists(true, Pellinore)
+	if !isTheQuestingBeastExists || !isPellinoreExists {
+		return nil
+	}
+	if board.quests.Flags[BEAST_VOTE_SEEN] ||
+		board.quests.Flags[BEAST_AND_PELLINORE_AT_SAME_QUEST] {
+		log.Println("not adding beast murder.")
+		return nil
+	}
+	return []Murder{{target: []string{beast.Player}, TargetCharacters: []string{TheQuestingBeast}, By: pellinore.Player}}
+}
 
-			if globalBoard.quests.Flags[BEAST_VOTE_SEEN] ||
-				globalBoard.quests.Flags[BEAST_AND_PELLINORE_AT_SAME_QUEST] {
-				log.Println("not adding beast murder.")
-			} else {
-				m := Murder{target: []string{beast.Player}, TargetCharacters: []string{TheQuestingBeast}, By: pellinore.Player}
-				murders = append(murders, m)
-			}
-		}
+// Cordana tries to kill Mordred after the bads won.
+func cordanaMurders(board *BoardGame, goodsWon bool) []Murder {
+	cordana, isCordanaExists := board.CharacterToPlayer[Cordana]
+	mordred, isMordredExists := board.CharacterToPlayer[Mordred]
+	if goodsWon || !isCordanaExists || !isMordredExists {
+		return nil
 	}
+	return []Murder{{target: []string{mordred.Player}, TargetCharacters: []string{Cordana}, By: cordana.Player, StateAfterSuccess: MurdersAfterGoodVictory}}
+}
 
-	if _, isKingClaudinExists := globalBoard.CharacterToPlayer[KingClaudin]; isKingClaudinExists {
-		if _, isPrinceClaudinExists := globalBoard.CharacterToPlayer[PrinceClaudin]; isPrinceClaudinExists {
-			if percivalPlayerName, isPercivalExists := globalBoard.CharacterToPlayer[Percival]; isPercivalExists {
-				m := Murder{target: getAllBads(), TargetCharacters: getAllBadsChars(), By: percivalPlayerName.Player, StateAfterSuccess: VictoryForGood}
-				murders = append(murders, m)
-			} else if arthurPlayerName, isArthurExists := globalBoard.CharacterToPlayer[KingArthur]; isArthurExists {
-				m := Murder{target: getAllBads(), TargetCharacters: getAllBadsChars(), By: arthurPlayerName.Player, StateAfterSuccess: VictoryForGood}
-				murders = append(murders, m)
-			}
-		}
+func (board *BoardGame) hasClaudins() bool {
+	_, isKingClaudinExists := board.CharacterToPlayer[KingClaudin]
+	_, isPrinceClaudinExists := board.CharacterToPlayer[PrinceClaudin]
+	return isKingClaudinExists && isPrinceClaudinExists
+}
+
+// With both Claudins, Percival has to name all the bads after the goods won.
+func percivalMurders(board *BoardGame, goodsWon bool) []Murder {
+	percivalPlayerName, isPercivalExists := board.CharacterToPlayer[Percival]
+	if !goodsWon || !isPercivalExists || !board.hasClaudins() {
+		return nil
 	}
+	return []Murder{{target: board.getAllBads(), TargetCharacters: board.getAllBadsChars(), By: percivalPlayerName.Player, StateAfterSuccess: VictoryForGood}}
+}
 
-	merlinAppenticePlayerName, ok := globalBoard.CharacterToPlayer[MerlinApprentice]
-	targetCharacters := make([]string, 0)
-	targetSlice := make([]string, 0)
-	if ok {
-		targetCharacters = append(targetCharacters, MerlinApprentice)
-		targetSlice = append(targetSlice, merlinAppenticePlayerName.Player)
+// King Arthur names all the bads after the bads won, or instead of a missing Percival.
+func kingArthurMurders(board *BoardGame, goodsWon bool) []Murder {
+	arthurPlayerName, isArthurExists := board.CharacterToPlayer[KingArthur]
+	if !isArthurExists {
+		return nil
+	}
+	if goodsWon {
+		if _, isPercivalExists := board.CharacterToPlayer[Percival]; isPercivalExists || !board.hasClaudins() {
+			return nil
+		}
 	}
-	assassin := globalBoard.CharacterToPlayer[Assassin]
+	return []Murder{{target: board.getAllBads(), TargetCharacters: board.getAllBadsChars(), By: arthurPlayerName.Player, StateAfterSuccess: VictoryForGood}}
+}
 
-	if merlinPlayerName, isMerlinExists := globalBoard.CharacterToPlayer[Merlin]; isMerlinExists {
-		targetSlice = append(targetSlice, merlinPlayerName.Player)
-		targetCharacters = append(targetCharacters, Merlin)
+// The assassin tries to kill one of the assassin targets, or the lovers, after the goods won.
+func assassinMurders(board *BoardGame, goodsWon bool) []Murder {
+	if !goodsWon {
+		return nil
 	}
-	if vivianPlayerName, isVivianExists := globalBoard.CharacterToPlayer[Viviana]; isVivianExists {
-		targetSlice = append(targetSlice, vivianPlayerName.Player)
-		targetCharacters = append(targetCharacters, Viviana)
+	if board.targeting {
+		return targetingMurders(board)
 	}
-	if nirlemPlayerName, isNirlemExists := globalBoard.CharacterToPlayer[Nirlem]; isNirlemExists {
-		targetSlice = append(targetSlice, nirlemPlayerName.Player)
-		targetCharacters = append(targetCharacters, Nirlem)
+	targetCharacters := make([]string, 0)
+	targetSlice := make([]string, 0)
+	for _, rules := range characterRegistry {
+		if !rules.AssassinTarget {
+			continue
+		}
+		if targetPlayerName, ok := board.CharacterToPlayer[rules.Name]; ok {
+			targetSlice = append(targetSlice, targetPlayerName.Player)
+			targetCharacters = append(targetCharacters, rules.Name)
+		}
 	}
 
-	if tristan, isTristanExists := globalBoard.CharacterToPlayer[Tristan]; isTristanExists {
-		if iseult, isIseultExists := globalBoard.CharacterToPlayer[Iseult]; isIseultExists {
+	if tristan, isTristanExists := board.CharacterToPlayer[Tristan]; isTristanExists {
+		if iseult, isIseultExists := board.CharacterToPlayer[Iseult]; isIseultExists {
 			targetSlice = append(targetSlice, tristan.Player)
 			targetSlice = append(targetSlice, iseult.Player)
-			targetCharacters = append(targetCharacters, "The-Lovers")
+			targetCharacters = append(targetCharacters, TheLovers)
 		}
 	}
 
-	m := Murder{target: targetSlice, TargetCharacters: targetCharacters, By: assassin.Player, ByCharacter: Assassin, StateAfterSuccess: VictoryForBad}
-	murders = append(murders, m)
-
-	return murders, len(murders) > 0
+	assassin := board.CharacterToPlayer[Assassin]
+	return []Murder{{target: targetSlice, TargetCharacters: targetCharacters, By: assassin.Player, ByCharacter: Assassin, StateAfterSuccess: VictoryForBad}}
 }
 
-func GetMurdersAfterBadsWins() ([]Murder, bool) {
-
-	murders := make([]Murder, 0)
-
-	if beast, isTheQuestingBeastExists := isCharacterExists(true, TheQuestingBeast); isTheQuestingBeastExists {
-		if pellinore, isPellinoreExists := isCharacterExists(true, Pellinore); isPellinoreExists {
-
-			if globalBoard.quests.Flags[BEAST_VOTE_SEEN] ||
-				globalBoard.quests.Flags[BEAST_AND_PELLINORE_AT_SAME_QUEST] {
-				log.Println("not adding beast murder.")
-			} else {
-				m := Murder{target: []string{beast.Player}, TargetCharacters: []string{TheQuestingBeast}, By: pellinore.Player}
-				murders = append(murders, m)
-			}
-		}
-	}
-
-	if cordana, isKingClaudinExists := globalBoard.CharacterToPlayer[Cordana]; isKingClaudinExists {
-		if mordred, isPrinceClaudinExists := globalBoard.CharacterToPlayer[Mordred]; isPrinceClaudinExists {
-			m := Murder{target: []string{mordred.Player}, TargetCharacters: []string{Cordana}, By: cordana.Player, StateAfterSuccess: MurdersAfterGoodVictory}
-			murders = append(murders, m)
-		}
+// The Untrustworthy Servant turns bad the first time the Assassin names him, and the Assassin tries again.
+func untrustworthyServantAssassinated(board *BoardGame, player PlayerName) bool {
+	if board.convertedPlayers[player.Player] != "" {
+		return false
 	}
-
-	if kingArthur, isKingArthurExists := globalBoard.CharacterToPlayer[KingArthur]; isKingArthurExists {
-		m := Murder{target: getAllBads(), TargetCharacters: getAllBadsChars(), By: kingArthur.Player, StateAfterSuccess: VictoryForGood}
-		murders = append(murders, m)
+	if board.convertedPlayers == nil {
+		board.convertedPlayers = make(map[string]string)
 	}
-
-
-	return murders, len(murders) > 0
+	log.Println(player.Player, "was named by the assassin and turns bad")
+	board.convertedPlayers[player.Player] = BAD
+	return true
 }
 
-func getAllBadsChars() []string {
+func (board *BoardGame) getAllBadsChars() []string {
 	allBads := make([]string, 0)
-	for _, player := range globalBoard.PlayerNames {
-		if ch, ok := globalBoard.PlayerToCharacter[player]; ok {
+	for _, player := range board.PlayerNames {
+		if ch, ok := board.PlayerToCharacter[player]; ok {
 			if _, ok := badCharacters[ch]; ok {
 				allBads = append(allBads, ch)
 			}
@@ -252,10 +310,10 @@ func getAllBadsChars() []string {
 	return allBads
 }
 
-func getAllBads() []string {
+func (board *BoardGame) getAllBads() []string {
 	allBads := make([]string, 0)
-	for _, player := range globalBoard.PlayerNames {
-		if ch, ok := globalBoard.PlayerToCharacter[player]; ok {
+	for _, player := range board.PlayerNames {
+		if ch, ok := board.PlayerToCharacter[player]; ok {
 			if _, ok := badCharacters[ch]; ok {
 				allBads = append(allBads, player.Player)
 			}
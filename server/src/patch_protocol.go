@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"sync"
+)
+
+const ( // protocols for board updates
+	ProtocolFull  = "full"
+	ProtocolPatch = "patch"
+)
+
+// maxUnacknowledgedStates bounds the states kept while waiting for a client's ack.
+const maxUnacknowledgedStates = 32
+
+// StateUpdate is sent instead of a full board message to clients in patch mode.
+// "snapshot" carries the whole state, "patch" carries RFC 6902 operations against the state of Base.
+type StateUpdate struct {
+	Type   string           `json:"ty"`
+	Sender string           `json:"sender,omitempty"`
+	Seq    int64            `json:"seq"`
+	Base   int64            `json:"base,omitempty"`
+	State  interface{}      `json:"state,omitempty"`
+	Patch  []PatchOperation `json:"patch,omitempty"`
+}
+
+type patchState struct {
+	mutex   sync.Mutex
+	enabled bool
+	base    interface{}           // last state the client acknowledged
+	baseSeq int64
+	sent    map[int64]interface{} // states sent but not acknowledged yet
+}
+
+func newPatchState(enabled bool) *patchState {
+	return &patchState{enabled: enabled, sent: make(map[int64]interface{})}
+}
+
+func (p *patchState) isEnabled() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.enabled
+}
+
+func (p *patchState) setEnabled(enabled bool) {
+	p.mutex.Lock()
+	p.enabled = enabled
+	p.mutex.Unlock()
+	p.reset()
+}
+
+// reset forgets the acknowledged state, so the next update is a full snapshot.
+func (p *patchState) reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.base = nil
+	p.baseSeq = 0
+	p.sent = make(map[int64]interface{})
+}
+
+func (p *patchState) ack(seq int64) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	state, ok := p.sent[seq]
+	if !ok {
+		return false
+	}
+	p.base = state
+	p.baseSeq = seq
+	for s := range p.sent {
+		if s <= seq {
+			delete(p.sent, s)
+		}
+	}
+	return true
+}
+
+// update returns the message that brings the client from its acknowledged state to state.
+func (p *patchState) update(sender string, seq int64, state interface{}) StateUpdate {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.sent) >= maxUnacknowledgedStates {
+		oldest := seq
+		for s := range p.sent {
+			if s < oldest {
+				oldest = s
+			}
+		}
+		delete(p.sent, oldest)
+	}
+	p.sent[seq] = state
+	if p.base == nil {
+		return StateUpdate{Type: "snapshot", Sender: sender, Seq: seq, State: state}
+	}
+	return StateUpdate{Type: "patch", Sender: sender, Seq: seq, Base: p.baseSeq, Patch: diffJSON(p.base, state)}
+}
+
+func (manager *ClientManager) patchMessage(msg *Message, conn *Client) []byte {
+	gm := manager.board.GetGameState(conn.id)
+	jsonState, _ := json.Marshal(&gm)
+	var state interface{}
+	json.Unmarshal(jsonState, &state)
+
+	update := conn.patch.update(msg.Sender, msg.Seq, state)
+	message, _ := json.Marshal(&update)
+	log.Println("Going to send", update.Type, "to", conn.id, "(", len(message), "bytes instead of", len(jsonState), ")")
+	return message
+}
+
+func handleSetProtocol(c *Client, board *BoardGame, envelope *Envelope) error {
+	var protocol string
+	if err := envelope.decode(&protocol); err != nil {
+		return err
+	}
+	c.patch.setEnabled(protocol == ProtocolPatch)
+	c.requestBoard()
+	return nil
+}
+
+func handleAckState(c *Client, board *BoardGame, envelope *Envelope) error {
+	var seq int64
+	if err := envelope.decode(&seq); err != nil {
+		return err
+	}
+	if !c.patch.ack(seq) {
+		return newCommandError(ErrBadPayload, "unknown state "+strconv.FormatInt(seq, 10))
+	}
+	return nil
+}
+
+func handleResync(c *Client, board *BoardGame, envelope *Envelope) error {
+	log.Println("client", c.id, "lost sync. sending full snapshot")
+	c.patch.reset()
+	c.requestBoard()
+	return nil
+}
+
+// requestBoard sends the current board to this client only.
+func (c *Client) requestBoard() {
+	if c.room == nil {
+		return
+	}
+	jsonMessage, _ := json.Marshal(&Message{Sender: c.id, Recipient: c.id, Content: "board"})
+	c.room.manager.broadcast <- jsonMessage
+}
@@ -0,0 +1,470 @@
+package main
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	StrongLeader          = "Strong-Leader"
+	TakeResponsibility    = "Take-Responsibility"
+	OverheardConversation = "Overheard-Conversation"
+	EstablishConfidence   = "Establish-Confidence"
+	OpinionMaker          = "Opinion-Maker"
+	KeepingACloseEye      = "Keeping-A-Close-Eye-On-You"
+	NoConfidence          = "No-Confidence"
+	InTheSpotlight        = "In-The-Spotlight"
+)
+
+const (
+	PlotImmediate = "immediate" // played by its holder before the team is suggested
+	PlotKept      = "kept"      // held until its holder plays it
+	PlotPermanent = "permanent" // works as long as it is held
+)
+
+const (
+	PlotDealt  = "dealt"
+	PlotPlayed = "played"
+)
+
+/*
+PlotCard is one kind of card in the Plot Cards deck. Play resolves the card for its holder, and
+returns an error when the card cannot be played on target now.
+*/
+type PlotCard struct {
+	Name       string
+	Count      int
+	Timing     string
+	PlayableIn []int // the states the holder may play the card in
+	Play       func(board *BoardGame, holder string, target string) error
+}
+
+var plotCards []PlotCard
+
+// the plays look cards up in the table, so it is filled at init
+func init() {
+	plotCards = []PlotCard{
+		{Name: StrongLeader, Count: 3, Timing: PlotKept, PlayableIn: []int{WaitingForSuggestion}, Play: playStrongLeader},
+		{Name: TakeResponsibility, Count: 1, Timing: PlotImmediate, PlayableIn: []int{WaitingForSuggestion}, Play: playTakeResponsibility},
+		{Name: OverheardConversation, Count: 2, Timing: PlotImmediate, PlayableIn: []int{WaitingForSuggestion}, Play: playOverheardConversation},
+		{Name: EstablishConfidence, Count: 1, Timing: PlotImmediate},
+		{Name: OpinionMaker, Count: 2, Timing: PlotPermanent},
+		{Name: KeepingACloseEye, Count: 2, Timing: PlotKept, PlayableIn: []int{JorneyVoting}, Play: playKeepingACloseEye},
+		{Name: NoConfidence, Count: 2, Timing: PlotKept, PlayableIn: []int{JorneyVoting}, Play: playNoConfidence},
+		{Name: InTheSpotlight, Count: 1, Timing: PlotKept, PlayableIn: []int{JorneyVoting}, Play: playInTheSpotlight},
+	}
+}
+
+func plotCard(name string) *PlotCard {
+	for i := range plotCards {
+		if plotCards[i].Name == name {
+			return &plotCards[i]
+		}
+	}
+	return nil
+}
+
+type PlotPlay struct {
+	PlayerName string `json:"playerName"`
+	Card       string `json:"card"`
+	Target     string `json:"target,omitempty"`
+}
+
+type PlotEvent struct {
+	Action string `json:"action"` // PlotDealt or PlotPlayed
+	Card   string `json:"card"`
+	Player string `json:"player"` // the holder
+	Target string `json:"target,omitempty"`
+	Result string `json:"result,omitempty"` // the quest card In The Spotlight showed
+}
+
+// PlotCardsView is the public side of the plot cards: the cards are held face up.
+type PlotCardsView struct {
+	Holdings     map[string][]string `json:"holdings"`
+	Deck         int                 `json:"deck"`
+	OpinionVotes map[string]bool     `json:"opinionVotes,omitempty"`
+}
+
+type PlotStats struct {
+	enabled       bool
+	deck          []string
+	discard       []string
+	drawn         []string            // drawn by the leader and not dealt yet
+	holdings      map[string][]string // player -> the cards the player holds
+	pendingEvents []PlotEvent         // events of the turn, archived with its suggestion
+	watchers      map[string][]string // quest member -> the players that see the member's quest card
+	spotlight     map[string]bool     // quest members whose quest card is shown to everyone
+	acceptedAfter int                 // unsuccessful retries before the current team was approved
+	vetoBefore    string              // the veto before the current team was approved
+}
+
+// startPlotCards shuffles the deck and lets the first leader draw.
+func (board *BoardGame) startPlotCards(enabled bool) {
+	board.plot = PlotStats{enabled: enabled}
+	if !enabled {
+		return
+	}
+	board.plot.holdings = make(map[string][]string)
+	for _, card := range plotCards {
+		for i := 0; i < card.Count; i++ {
+			board.plot.deck = append(board.plot.deck, card.Name)
+		}
+	}
+	board.rng.Shuffle(len(board.plot.deck), func(i, j int) {
+		board.plot.deck[i], board.plot.deck[j] = board.plot.deck[j], board.plot.deck[i]
+	})
+	board.drawPlotCards()
+}
+
+// plotCardsPerTurn is the number of cards a leader draws: 1 up to 6 players, 2 up to 8 and 3 from 9.
+func (board *BoardGame) plotCardsPerTurn() int {
+	if board.numOfPlayers <= 6 {
+		return 1
+	}
+	if board.numOfPlayers <= 8 {
+		return 2
+	}
+	return 3
+}
+
+func (board *BoardGame) leader() string {
+	return board.PlayerNames[board.suggestions.suggesterIndex%len(board.PlayerNames)].Player
+}
+
+// drawPlotCards starts the turn of a new leader. The discard pile is shuffled back when the deck runs out.
+func (board *BoardGame) drawPlotCards() {
+	if !board.plot.enabled {
+		return
+	}
+	board.plot.discard = append(board.plot.discard, board.plot.drawn...)
+	board.plot.drawn = make([]string, 0)
+	board.plot.watchers = make(map[string][]string)
+	board.plot.spotlight = make(map[string]bool)
+	for i := 0; i < board.plotCardsPerTurn(); i++ {
+		if len(board.plot.deck) == 0 {
+			board.plot.deck, board.plot.discard = board.plot.discard, nil
+			board.rng.Shuffle(len(board.plot.deck), func(i, j int) {
+				board.plot.deck[i], board.plot.deck[j] = board.plot.deck[j], board.plot.deck[i]
+			})
+		}
+		if len(board.plot.deck) == 0 {
+			break
+		}
+		board.plot.drawn = append(board.plot.drawn, board.plot.deck[0])
+		board.plot.deck = board.plot.deck[1:]
+	}
+	log.Println(board.leader(), "drew plot cards:", board.plot.drawn)
+	board.showPlotDraw("", board.leader())
+}
+
+// showPlotDraw moves the drawn cards from the secrets of one leader to the next.
+func (board *BoardGame) showPlotDraw(from string, to string) {
+	if secrets := board.SecretsMap[from]; secrets != nil {
+		secrets.PlotDraw = nil
+	}
+	if secrets := board.SecretsMap[to]; secrets != nil {
+		secrets.PlotDraw = append([]string{}, board.plot.drawn...)
+	}
+}
+
+// PlotDealHandler gives the drawn cards, in order, to the players named. Every card goes to a different player.
+func (board *BoardGame) PlotDealHandler(players []string) error {
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
+
+	if !board.plot.enabled {
+		return newCommandError(ErrWrongState, "plot cards are not in this game")
+	}
+	if len(players) != len(board.plot.drawn) {
+		return newCommandError(ErrInvalidTarget, "deal "+strconv.Itoa(len(board.plot.drawn))+" cards, got "+
+			strconv.Itoa(len(players))+" players")
+	}
+	leader := board.leader()
+	dealtTo := make(map[string]bool)
+	for _, player := range players {
+		if player == leader || dealtTo[player] || !board.isActivePlayer(player) {
+			return newCommandError(ErrInvalidTarget, "cannot deal a plot card to "+player)
+		}
+		dealtTo[player] = true
+	}
+
+	for i, player := range players {
+		card := board.plot.drawn[i]
+		board.recordPlotEvent(PlotEvent{Action: PlotDealt, Card: card, Player: player}, board.State)
+		if card == EstablishConfidence {
+			// the leader's loyalty is shown to the new holder, and the card is done
+			board.revealLoyaltyTo(player, leader)
+			board.plot.discard = append(board.plot.discard, card)
+			continue
+		}
+		board.plot.holdings[player] = append(board.plot.holdings[player], card)
+	}
+	board.plot.drawn = make([]string, 0)
+	board.showPlotDraw(leader, "")
+	board.StateDescription = "Plot Cards: " + leader + " dealt to " + strings.Join(players, ",")
+	return nil
+}
+
+// PlotPlayHandler plays a card the player holds.
+func (board *BoardGame) PlotPlayHandler(play PlotPlay) error {
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
+
+	card := plotCard(play.Card)
+	if !board.plot.enabled || card == nil {
+		return newCommandError(ErrInvalidTarget, "no plot card "+play.Card)
+	}
+	held := SliceIndex(len(board.plot.holdings[play.PlayerName]), func(i int) bool {
+		return board.plot.holdings[play.PlayerName][i] == play.Card
+	})
+	if held < 0 {
+		return newCommandError(ErrInvalidTarget, play.PlayerName+" does not hold "+play.Card)
+	}
+	if SliceIndex(len(card.PlayableIn), func(i int) bool { return card.PlayableIn[i] == board.State }) < 0 {
+		return newCommandError(ErrWrongState, play.Card+" cannot be played now")
+	}
+	if play.Target == play.PlayerName {
+		return newCommandError(ErrInvalidTarget, play.Card+" cannot target its holder")
+	}
+
+	state := board.State
+	if err := card.Play(board, play.PlayerName, play.Target); err != nil {
+		return err
+	}
+	board.removePlotCard(play.PlayerName, play.Card)
+	board.plot.discard = append(board.plot.discard, play.Card)
+	board.recordPlotEvent(PlotEvent{Action: PlotPlayed, Card: play.Card, Player: play.PlayerName, Target: play.Target}, state)
+	log.Println(play.PlayerName, "played", play.Card, "on", play.Target)
+	return nil
+}
+
+func (board *BoardGame) removePlotCard(player string, card string) {
+	cards := board.plot.holdings[player]
+	if i := SliceIndex(len(cards), func(i int) bool { return cards[i] == card }); i >= 0 {
+		board.plot.holdings[player] = removeElementFromStringSlice(cards, i)
+	}
+}
+
+// recordPlotEvent keeps the events of a turn until its suggestion is archived, and later ones with that suggestion.
+func (board *BoardGame) recordPlotEvent(event PlotEvent, state int) {
+	if state == WaitingForSuggestion || len(board.archive) == 0 {
+		board.plot.pendingEvents = append(board.plot.pendingEvents, event)
+		return
+	}
+	last := &board.archive[len(board.archive)-1]
+	last.PlotEvents = append(last.PlotEvents, event)
+}
+
+// takePlotEvents hands the events of the turn to the suggestion that ends it.
+func (board *BoardGame) takePlotEvents() []PlotEvent {
+	events := board.plot.pendingEvents
+	board.plot.pendingEvents = nil
+	return events
+}
+
+func (board *BoardGame) revealLoyaltyTo(holder string, target string) {
+	loyalty := board.inspectedLoyalty(target)
+	board.Secrets[holder] = append(board.Secrets[holder], target+" is "+strings.ToLower(loyalty))
+	secrets := board.SecretsMap[holder]
+	if secrets == nil {
+		return
+	}
+	switch loyalty {
+	case GOOD:
+		secrets.PlayersWithGoodCharacter = append(secrets.PlayersWithGoodCharacter, target)
+	case BAD:
+		secrets.PlayersWithBadCharacter = append(secrets.PlayersWithBadCharacter, target)
+	}
+}
+
+// validatePlotTurn checks that the leader dealt the plot cards and the immediate ones were played.
+func (board *BoardGame) validatePlotTurn() error {
+	if !board.plot.enabled {
+		return nil
+	}
+	if len(board.plot.drawn) > 0 {
+		return newCommandError(ErrWrongState, "the leader has to deal the plot cards first")
+	}
+	for _, player := range board.PlayerNames {
+		for _, card := range board.plot.holdings[player.Player] {
+			if plotCard(card).Timing == PlotImmediate {
+				return newCommandError(ErrWrongState, player.Player+" has to play "+card+" first")
+			}
+		}
+	}
+	return nil
+}
+
+// validateOpinionVote lets the holders of Opinion Maker vote on a suggestion before everyone else.
+func (board *BoardGame) validateOpinionVote(voter string) error {
+	if !board.plot.enabled || board.holdsPlotCard(voter, OpinionMaker) {
+		return nil
+	}
+	for _, player := range board.PlayerNames {
+		if _, voted := board.votesForNextMission[player.Player]; !voted && board.holdsPlotCard(player.Player, OpinionMaker) {
+			return newCommandError(ErrNotYourTurn, player.Player+" is an opinion maker and votes first")
+		}
+	}
+	return nil
+}
+
+func (board *BoardGame) holdsPlotCard(player string, card string) bool {
+	cards := board.plot.holdings[player]
+	return SliceIndex(len(cards), func(i int) bool { return cards[i] == card }) >= 0
+}
+
+// plotTeamApproved keeps what No Confidence needs to undo the approval of the team.
+func (board *BoardGame) plotTeamApproved() {
+	board.plot.acceptedAfter = board.suggestions.unsuccessfulRetries
+	board.plot.vetoBefore = board.suggestions.PlayerWithVeto
+}
+
+// plotQuestCard shows a quest card to the players keeping an eye on its player, or to everyone in the spotlight.
+func (board *BoardGame) plotQuestCard(player string, vote int, curEntry *QuestArchiveItem) {
+	if !board.plot.enabled {
+		return
+	}
+	for _, watcher := range board.plot.watchers[player] {
+		board.Secrets[watcher] = append(board.Secrets[watcher], player+" played "+getVoteStr(vote))
+		if secrets := board.SecretsMap[watcher]; secrets != nil {
+			if secrets.PlotSeenVotes == nil {
+				secrets.PlotSeenVotes = make(map[string]string)
+			}
+			secrets.PlotSeenVotes[player] = getVoteStr(vote)
+		}
+	}
+	if board.plot.spotlight[player] {
+		for i := range curEntry.PlotEvents {
+			if curEntry.PlotEvents[i].Card == InTheSpotlight && curEntry.PlotEvents[i].Target == player {
+				curEntry.PlotEvents[i].Result = getVoteStr(vote)
+			}
+		}
+	}
+}
+
+func (board *BoardGame) plotCardsView() *PlotCardsView {
+	if !board.plot.enabled {
+		return nil
+	}
+	view := &PlotCardsView{Holdings: make(map[string][]string), Deck: len(board.plot.deck)}
+	for player, cards := range board.plot.holdings {
+		if len(cards) > 0 {
+			view.Holdings[player] = append([]string{}, cards...)
+		}
+		if vote, voted := board.votesForNextMission[player]; voted && board.State == SuggestionVoting &&
+			board.holdsPlotCard(player, OpinionMaker) {
+			if view.OpinionVotes == nil {
+				view.OpinionVotes = make(map[string]bool)
+			}
+			view.OpinionVotes[player] = vote
+		}
+	}
+	return view
+}
+
+// Strong Leader makes its holder the leader, before the cards of the turn are dealt.
+func playStrongLeader(board *BoardGame, holder string, target string) error {
+	if len(board.plot.drawn) == 0 {
+		return newCommandError(ErrWrongState, "the leader already dealt the plot cards")
+	}
+	index := SliceIndex(len(board.PlayerNames), func(i int) bool { return board.PlayerNames[i].Player == holder })
+	if index < 0 {
+		return newCommandError(ErrNotSeated, holder+" is not seated")
+	}
+	previous := board.leader()
+	board.suggestions.suggesterIndex = index
+	board.showPlotDraw(previous, holder)
+	board.StateDescription = "Strong Leader: " + holder + " is the leader instead of " + previous
+	return nil
+}
+
+// Take Responsibility takes a kept or permanent card from another player. Without such a card it is discarded.
+func playTakeResponsibility(board *BoardGame, holder string, target string) error {
+	if target == "" {
+		for player, cards := range board.plot.holdings {
+			for _, card := range cards {
+				if player != holder && plotCard(card).Timing != PlotImmediate {
+					return newCommandError(ErrInvalidTarget, "name a player to take a card from")
+				}
+			}
+		}
+		return nil
+	}
+	cards := board.plot.holdings[target]
+	taken := SliceIndex(len(cards), func(i int) bool { return plotCard(cards[i]).Timing != PlotImmediate })
+	if taken < 0 {
+		return newCommandError(ErrInvalidTarget, target+" holds no card to take")
+	}
+	card := cards[taken]
+	board.plot.holdings[target] = removeElementFromStringSlice(cards, taken)
+	board.plot.holdings[holder] = append(board.plot.holdings[holder], card)
+	board.StateDescription = "Take Responsibility: " + holder + " took " + card + " from " + target
+	return nil
+}
+
+// Overheard Conversation shows its holder the loyalty of a neighbour.
+func playOverheardConversation(board *BoardGame, holder string, target string) error {
+	n := len(board.PlayerNames)
+	seat := SliceIndex(n, func(i int) bool { return board.PlayerNames[i].Player == holder })
+	if seat < 0 || (board.PlayerNames[(seat+1)%n].Player != target && board.PlayerNames[(seat+n-1)%n].Player != target) {
+		return newCommandError(ErrInvalidTarget, target+" does not sit next to "+holder)
+	}
+	board.revealLoyaltyTo(holder, target)
+	return nil
+}
+
+// validateQuestTarget checks that target is on the quest and did not play a quest card yet.
+func (board *BoardGame) validateQuestTarget(target string) error {
+	onQuest := SliceIndex(len(board.suggestions.SuggestedPlayers), func(i int) bool {
+		return board.suggestions.SuggestedPlayers[i] == target
+	}) >= 0
+	if !onQuest {
+		return newCommandError(ErrInvalidTarget, target+" is not on the quest")
+	}
+	if _, voted := board.quests.playerVotedForCurrent[target]; voted {
+		return newCommandError(ErrInvalidTarget, target+" already played a quest card")
+	}
+	return nil
+}
+
+// Keeping A Close Eye On You shows its holder the quest card of a team member.
+func playKeepingACloseEye(board *BoardGame, holder string, target string) error {
+	if err := board.validateQuestTarget(target); err != nil {
+		return err
+	}
+	board.plot.watchers[target] = append(board.plot.watchers[target], holder)
+	return nil
+}
+
+// In The Spotlight shows everyone the quest card of a team member.
+func playInTheSpotlight(board *BoardGame, holder string, target string) error {
+	if err := board.validateQuestTarget(target); err != nil {
+		return err
+	}
+	board.plot.spotlight[target] = true
+	return nil
+}
+
+// No Confidence rejects an approved team before its quest starts. The last team of a quest cannot be rejected.
+func playNoConfidence(board *BoardGame, holder string, target string) error {
+	if len(board.quests.playerVotedForCurrentQuest) > 0 {
+		return newCommandError(ErrWrongState, "the quest already started")
+	}
+	if board.plot.acceptedAfter >= board.retriesFor(board.quests.current)-1 {
+		return newCommandError(ErrWrongState, "the last team of a quest cannot be rejected")
+	}
+	curEntry := &board.archive[len(board.archive)-1]
+	curEntry.IsSuggestionAccepted = false
+	board.suggestions.unsuccessfulRetries = board.plot.acceptedAfter + 1
+	board.suggestions.PlayerWithVeto = board.plot.vetoBefore
+	board.QuestStage = board.LastQuestStage + 0.1
+	board.QuestStage = float32(math.Round(float64(board.QuestStage*100)) / 100)
+
+	// the turn already passed to the next leader when the team was approved
+	board.setState(WaitingForSuggestion)
+	board.StateDescription = "No Confidence: " + holder + " rejected the team. Suggestion For Next Quest: " +
+		board.leader() + " is choosing players..."
+	return nil
+}
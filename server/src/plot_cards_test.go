@@ -0,0 +1,191 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_PlotCards(t *testing.T) {
+	t.Run("Lets the leader draw and deal before the suggestion", should_deal_plot_cards_before_suggestion)
+	t.Run("Rejects dealing to the leader or twice to a player", should_reject_invalid_deal)
+	t.Run("Makes the Strong Leader the leader", should_make_strong_leader_the_leader)
+	t.Run("Lets opinion makers vote first", should_let_opinion_makers_vote_first)
+	t.Run("Rejects an approved team with No Confidence", should_reject_team_with_no_confidence)
+	t.Run("Shows quest cards to a watcher and in the spotlight", should_show_quest_cards)
+}
+
+func newPlotGame(t *testing.T) *BoardGame {
+	return startTestGame(t, GameConfiguration{PlotCards: true}, Merlin, Percival, LoyalServentOfArthur, Assassin, Morgana)
+}
+
+// rigPlotDraw replaces the cards the leader drew.
+func rigPlotDraw(board *BoardGame, cards ...string) {
+	board.plot.drawn = cards
+	board.showPlotDraw("", board.leader())
+}
+
+func should_deal_plot_cards_before_suggestion(t *testing.T) {
+	//Arrange
+	board := newPlotGame(t)
+	rigPlotDraw(board, StrongLeader)
+	leader, others := board.leader(), otherPlayers(board)
+
+	//Act
+	before := board.HandleNewSuggest(Suggestion{Players: others[:2]})
+	secrets := board.GetGameState(leader).PlayerSecrets.PlotDraw
+	err := board.PlotDealHandler([]string{others[0]})
+
+	//Assert
+	if cerr, ok := before.(*CommandError); !ok || cerr.Code != ErrWrongState {
+		t.Error("The suggestion should wait for the deal, got", before)
+	}
+	if len(secrets) != 1 || secrets[0] != StrongLeader {
+		t.Error("The leader should see the drawn card, got", secrets)
+	}
+	if err != nil {
+		t.Fatal("The deal should be accepted, got", err)
+	}
+	if err := board.HandleNewSuggest(Suggestion{Players: others[:2]}); err != nil {
+		t.Fatal("The suggestion should be accepted after the deal, got", err)
+	}
+	archive := board.GetGameState(leader).Archive
+	entry := archive[len(archive)-1]
+	if len(entry.PlotEvents) != 1 || entry.PlotEvents[0].Action != PlotDealt || entry.PlotEvents[0].Player != others[0] {
+		t.Error("The deal should be archived with the suggestion, got", entry.PlotEvents)
+	}
+	if view := board.GetGameState(leader).PlotCards; view == nil || view.Holdings[others[0]][0] != StrongLeader {
+		t.Error("The holdings should be public, got", view)
+	}
+}
+
+func should_reject_invalid_deal(t *testing.T) {
+	//Arrange
+	board := newPlotGame(t)
+	rigPlotDraw(board, StrongLeader, OpinionMaker)
+	others := otherPlayers(board)
+
+	for _, deal := range [][]string{{board.leader(), others[0]}, {others[0], others[0]}, {others[0]}} {
+		//Act
+		err := board.PlotDealHandler(deal)
+
+		//Assert
+		if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidTarget {
+			t.Error("Expected invalid_target for", deal, "got", err)
+		}
+	}
+}
+
+func should_make_strong_leader_the_leader(t *testing.T) {
+	//Arrange
+	board := newPlotGame(t)
+	others := otherPlayers(board)
+	rigPlotDraw(board, StrongLeader)
+	board.PlotDealHandler([]string{others[2]})
+	board.HandleNewSuggest(Suggestion{Players: others[:2]})
+	for _, player := range board.PlayerNames {
+		board.HandleSuggestionVote(VoteForSuggestion{PlayerName: player.Player, Vote: false})
+	}
+	drawn := board.SecretsMap[board.leader()].PlotDraw
+
+	//Act
+	err := board.PlotPlayHandler(PlotPlay{PlayerName: others[2], Card: StrongLeader})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Strong Leader should be played, got", err)
+	}
+	if board.leader() != others[2] || len(board.GetGameState(others[2]).PlotCards.Holdings[others[2]]) != 0 {
+		t.Error("The holder should lead, got", board.leader(), board.GetGameState(others[2]).PlotCards.Holdings)
+	}
+	if draw := board.SecretsMap[others[2]].PlotDraw; len(drawn) != 1 || !sameStringSlice(draw, drawn) {
+		t.Error("The new leader should get the drawn cards, got", draw, drawn)
+	}
+}
+
+func should_let_opinion_makers_vote_first(t *testing.T) {
+	//Arrange
+	board := newPlotGame(t)
+	others := otherPlayers(board)
+	rigPlotDraw(board, OpinionMaker)
+	board.PlotDealHandler([]string{others[1]})
+	board.HandleNewSuggest(Suggestion{Players: others[:2]})
+
+	//Act
+	early := board.HandleSuggestionVote(VoteForSuggestion{PlayerName: others[0], Vote: true})
+	opinion := board.HandleSuggestionVote(VoteForSuggestion{PlayerName: others[1], Vote: false})
+
+	//Assert
+	if cerr, ok := early.(*CommandError); !ok || cerr.Code != ErrNotYourTurn {
+		t.Error("Expected not_your_turn before the opinion maker voted, got", early)
+	}
+	if opinion != nil {
+		t.Fatal("The opinion maker should vote, got", opinion)
+	}
+	if votes := board.GetGameState(others[0]).PlotCards.OpinionVotes; votes[others[1]] != false || len(votes) != 1 {
+		t.Error("The opinion maker's vote should be public, got", votes)
+	}
+	if err := board.HandleSuggestionVote(VoteForSuggestion{PlayerName: others[0], Vote: true}); err != nil {
+		t.Error("The others should vote after the opinion maker, got", err)
+	}
+}
+
+// approveTeam deals the cards to the first other player and the last one, and approves a team of the others.
+func approveTeam(t *testing.T, board *BoardGame, cards ...string) []string {
+	others := otherPlayers(board)
+	holders := []string{others[0], others[len(others)-1]}
+	rigPlotDraw(board, cards...)
+	if err := board.PlotDealHandler(holders[:len(cards)]); err != nil {
+		t.Fatal("The deal should be accepted, got", err)
+	}
+	voteTeam(t, board, questTeam(board, others[1:]...))
+	return others
+}
+
+func should_reject_team_with_no_confidence(t *testing.T) {
+	//Arrange
+	board := newPlotGame(t)
+	others := approveTeam(t, board, NoConfidence)
+	nextLeader := board.leader()
+
+	//Act
+	err := board.PlotPlayHandler(PlotPlay{PlayerName: others[0], Card: NoConfidence})
+
+	//Assert
+	if err != nil {
+		t.Fatal("No Confidence should be played, got", err)
+	}
+	if board.State != WaitingForSuggestion || board.suggestions.unsuccessfulRetries != 1 || board.QuestStage != 1.1 {
+		t.Error("The team should be rejected, got", board.State, board.suggestions.unsuccessfulRetries, board.QuestStage)
+	}
+	archive := board.GetGameState(others[0]).Archive
+	entry := archive[len(archive)-1]
+	if entry.IsSuggestionAccepted || len(entry.PlotEvents) == 0 || entry.PlotEvents[len(entry.PlotEvents)-1].Card != NoConfidence {
+		t.Error("The archive should record the rejection, got", entry)
+	}
+	if board.leader() != nextLeader {
+		t.Error("The next leader should keep the turn, got", board.leader())
+	}
+}
+
+func should_show_quest_cards(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{PlotCards: true}, Merlin, Percival, LoyalServentOfArthurA,
+		LoyalServentOfArthurB, Assassin, Morgana, Mordred)
+	others := approveTeam(t, board, KeepingACloseEye, InTheSpotlight)
+	spotlighter := others[len(others)-1]
+	board.PlotPlayHandler(PlotPlay{PlayerName: others[0], Card: KeepingACloseEye, Target: others[1]})
+	board.PlotPlayHandler(PlotPlay{PlayerName: spotlighter, Card: InTheSpotlight, Target: others[2]})
+
+	//Act
+	board.HandleJourneyVote(VoteForJourney{PlayerName: others[1], Vote: VoteSuccess})
+	board.HandleJourneyVote(VoteForJourney{PlayerName: others[2], Vote: VoteSuccess})
+
+	//Assert
+	if seen := board.SecretsMap[others[0]].PlotSeenVotes[others[1]]; seen != "Success" {
+		t.Error("The watcher should see the quest card, got", seen)
+	}
+	archive := board.GetGameState(others[0]).Archive
+	events := archive[len(archive)-1].PlotEvents
+	if last := events[len(events)-1]; last.Card != InTheSpotlight || last.Result != "Success" {
+		t.Error("The spotlight should show the quest card to everyone, got", events)
+	}
+}
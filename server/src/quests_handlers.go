@@ -15,6 +15,9 @@ const (
 	VoteBeast       = 3
 	VoteAvalonPower = 5
 	VoteEmpty = 6
+	VoteMagic = 7
+	VoteRogueSuccess = 8 // counts as a Success
+	VoteRogueFail    = 9 // counts as a Fail
 )
 
 type VoteForJourney struct {
@@ -22,58 +25,42 @@ type VoteForJourney struct {
 	Vote       int    `json:"vote,omitempty"`
 }
 
-type VoteForJourneyMessage struct {
-	Tp      string         `json:"type"`
-	Content VoteForJourney `json:"content"`
-}
-
-func HandleJourneyVote(vote VoteForJourney) {
-	globalMutex.Lock()
-	defer globalMutex.Unlock()
-	current := globalBoard.quests.current
-
-	if globalBoard.State != JorneyVoting {
-		return
-	}
+func (board *BoardGame) HandleJourneyVote(vote VoteForJourney) error {
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
+	current := board.quests.current
 
-	if _, ok := globalBoard.quests.playerVotedForCurrent[vote.PlayerName]; ok {
-		return
+	if _, ok := board.quests.playerVotedForCurrent[vote.PlayerName]; ok {
+		return newCommandError(ErrAlreadyVoted, vote.PlayerName+" already voted for this quest")
 	}
 
-	if globalBoard.PlayerToCharacter[PlayerName{vote.PlayerName}] == Titanya &&
-		vote.Vote == VoteFail {
-		globalBoard.quests.Flags[TITANYA_FIRST_FAIL] = true
+	if err := board.validateJourneyVote(vote); err != nil {
+		return err
 	}
 
-	if globalBoard.PlayerToCharacter[PlayerName{vote.PlayerName}] == Elaine &&
-		vote.Vote == VoteAvalonPower {
-		globalBoard.quests.Flags[ELAINE_AVALON_POWER_CARD] = true
-	}
-
-	if globalBoard.PlayerToCharacter[PlayerName{vote.PlayerName}] == TheQuestingBeast &&
-		vote.Vote == VoteSuccess {
-		globalBoard.quests.Flags[BEAST_FIRST_SUCCESS] = true
+	if rules := characterRules(board.PlayerToCharacter[PlayerName{vote.PlayerName}]); rules != nil && rules.OnQuestCard != nil {
+		rules.OnQuestCard(board, vote.Vote)
 	}
 
 	origVote := vote.Vote
 	if origVote == VoteBeast {
-		globalBoard.quests.Flags[BEAST_VOTE_SEEN] = true
+		board.quests.Flags[BEAST_VOTE_SEEN] = true
 		origVote = VoteFail
 	}
 	log.Println(vote.PlayerName, " voted ", vote.Vote)
 
-	globalBoard.quests.playerVotedForCurrentQuest = append(globalBoard.quests.playerVotedForCurrentQuest, vote.PlayerName)
+	board.quests.playerVotedForCurrentQuest = append(board.quests.playerVotedForCurrentQuest, vote.PlayerName)
 
-	votedPlayersString := strings.Join(globalBoard.quests.playerVotedForCurrentQuest[:], ",")
-	globalBoard.StateDescription = " Voting for Quest " + strconv.Itoa(current+1) + "!" + votedPlayersString + " voted!"
+	votedPlayersString := strings.Join(board.quests.playerVotedForCurrentQuest[:], ",")
+	board.StateDescription = " Voting for Quest " + strconv.Itoa(current+1) + "!" + votedPlayersString + " voted!"
 
-	globalBoard.quests.playerVotedForCurrent[vote.PlayerName] = origVote
-	mp := append(globalBoard.quests.playersVotes[current], origVote)
+	board.quests.playerVotedForCurrent[vote.PlayerName] = origVote
+	mp := append(board.quests.playersVotes[current], origVote)
 
-	res := globalBoard.quests.results[current+1]
+	res := board.quests.results[current+1]
 	requiredVotes := res.NumOfPlayers
 
-	curEntry := globalBoard.archive[len(globalBoard.archive)-1] //Stats table
+	curEntry := board.archive[len(board.archive)-1] //Stats table
 	if vote.Vote == VoteFail {
 		res.NumOfFailures++
 		curEntry.NumberOfFailures++
@@ -89,66 +76,77 @@ func HandleJourneyVote(vote VoteForJourney) {
 	} else if vote.Vote == VoteEmpty {
 		res.NumOfEmpty++
 		curEntry.NumberOfEmpty++
+	} else if vote.Vote == VoteMagic {
+		res.NumOfMagic++
+		curEntry.NumberOfMagic++
+	} else if vote.Vote == VoteRogueSuccess {
+		res.NumOfRogueSuccess++
+		curEntry.NumberOfRogueSuccesses++
+	} else if vote.Vote == VoteRogueFail {
+		res.NumOfRogueFail++
+		curEntry.NumberOfRogueFailures++
 	}
+	board.plotQuestCard(vote.PlayerName, vote.Vote, &curEntry)
 
 
 	if len(mp) == requiredVotes { //last vote
-		if _, ok := globalBoard.quests.Flags[EXCALIBUR]; ok {
-			globalBoard.State = ExcaliburPick
+		if _, ok := board.quests.Flags[EXCALIBUR]; ok {
+			board.setState(ExcaliburPick)
 			//update info
-			globalBoard.StateDescription = "Excalibur: " + globalBoard.suggestions.excalibur.Player +
+			board.StateDescription = "Excalibur: " + board.suggestions.excalibur.Player +
 				" is deciding whether to reverse some vote or not..."
-			globalBoard.archive[len(globalBoard.archive)-1] = curEntry
-			globalBoard.quests.results[current+1] = res
-			globalBoard.quests.playersVotes[current] = mp
-			return
+			board.archive[len(board.archive)-1] = curEntry
+			board.quests.results[current+1] = res
+			board.quests.playersVotes[current] = mp
+			return nil
 		}
 
-		if StartNewSuggestion(mp, curEntry, current) {
-			return
+		if board.StartNewSuggestion(mp, curEntry, current) {
+			return nil
 		}
 
-		EndJourney(&res, mp, &curEntry, current)
+		board.EndJourney(&res, mp, &curEntry, current)
 	}
 
 	//update info
-	globalBoard.archive[len(globalBoard.archive)-1] = curEntry
-	globalBoard.quests.results[current+1] = res
-	globalBoard.quests.playersVotes[current] = mp
-	if _, ok := globalBoard.quests.Flags[EXCALIBUR]; !ok && len(mp) == requiredVotes { //last vote
-		globalBoard.quests.current++
+	board.archive[len(board.archive)-1] = curEntry
+	board.quests.results[current+1] = res
+	board.quests.playersVotes[current] = mp
+	if _, ok := board.quests.Flags[EXCALIBUR]; !ok && len(mp) == requiredVotes { //last vote
+		board.quests.current++
 	}
+	return nil
 }
 
-func StartNewSuggestion(mp []int, curEntry QuestArchiveItem, current int) bool {
+func (board *BoardGame) StartNewSuggestion(mp []int, curEntry QuestArchiveItem, current int) bool {
 	for _, vote := range mp {
 		if vote == VoteAvalonPower {
-			globalBoard.State = WaitingForSuggestion
-			suggesterIndex := globalBoard.suggestions.suggesterIndex
-			globalBoard.StateDescription = "Suggestion For Next Quest: " + globalBoard.PlayerNames[suggesterIndex].Player +
+			board.setState(WaitingForSuggestion)
+			suggesterIndex := board.suggestions.suggesterIndex
+			board.StateDescription = "Suggestion For Next Quest: " + board.PlayerNames[suggesterIndex].Player +
 				" is choosing players..."
 
 			curEntry.AvalonPower = true
 
-			playerWithVeto := globalBoard.suggestions.PlayerWithVeto
+			playerWithVeto := board.suggestions.PlayerWithVeto
 			vetoIndex := 0
-			for i, p := range globalBoard.PlayerNames {
+			for i, p := range board.PlayerNames {
 				if playerWithVeto == p.Player {
 					vetoIndex = i + 1
 					break
 				}
 			}
-			vetoIndex = vetoIndex % len(globalBoard.PlayerNames)
-			globalBoard.suggestions.PlayerWithVeto = globalBoard.PlayerNames[vetoIndex].Player
+			vetoIndex = vetoIndex % len(board.PlayerNames)
+			board.suggestions.PlayerWithVeto = board.PlayerNames[vetoIndex].Player
 
-			globalBoard.quests.playerVotedForCurrentQuest = make([]string, 0)
-			globalBoard.quests.playerVotedForCurrent = make(map[string]int)
-			globalBoard.votesForNextMission = make(map[string]bool) //for suggestions
-			globalBoard.suggestions.SuggestedPlayers = make([]string, 0)
-			globalBoard.quests.playersVotes[current] = make([]int, 0)
-			globalBoard.archive[len(globalBoard.archive)-1] = curEntry
+			board.quests.playerVotedForCurrentQuest = make([]string, 0)
+			board.quests.playerVotedForCurrent = make(map[string]int)
+			board.votesForNextMission = make(map[string]bool) //for suggestions
+			board.suggestions.SuggestedPlayers = make([]string, 0)
+			board.quests.playersVotes[current] = make([]int, 0)
+			board.archive[len(board.archive)-1] = curEntry
 
-			globalBoard.QuestStage = globalBoard.LastQuestStage
+			board.QuestStage = board.LastQuestStage
 
 			return true
 		}
@@ -156,25 +154,20 @@ func StartNewSuggestion(mp []int, curEntry QuestArchiveItem, current int) bool {
 	return false
 }
 
-func EndJourney(res *QuestStats, mp []int, curEntry *QuestArchiveItem, current int) {
-	retriesPerLevel := globalConfigPerNumOfPlayers[globalBoard.numOfPlayers].RetriesPerLevel
-	if globalBoard.quests.current+1 < len(retriesPerLevel) { //not last quest in game
-		numOfUnsuccesfulRetries := retriesPerLevel[globalBoard.quests.current+1]
-		suggesterVetoIn := (globalBoard.suggestions.suggesterIndex + numOfUnsuccesfulRetries - 1) % len(globalBoard.PlayerNames)
-		globalBoard.suggestions.PlayerWithVeto = globalBoard.PlayerNames[suggesterVetoIn].Player
-	}
-	res.Final = CalculateQuestResult(mp)
-	log.Println("Quest Result:(", globalBoard.quests.current+1, ")", res.Final)
+func (board *BoardGame) EndJourney(res *QuestStats, mp []int, curEntry *QuestArchiveItem, current int) {
+	board.setVetoPlayer(board.suggestions.suggesterIndex, board.quests.current+1)
+	res.Final = board.CalculateQuestResult(mp)
+	log.Println("Quest Result:(", board.quests.current+1, ")", res.Final)
 	curEntry.FinalResult = res.Final
-	globalBoard.quests.results[current+1] = *res
-	if globalBoard.quests.results[current+1].Final == JorneySuccess {
-		globalBoard.quests.successfulQuest++
+	board.quests.results[current+1] = *res
+	if board.quests.results[current+1].Final == JorneySuccess {
+		board.quests.successfulQuest++
 	} else {
-		globalBoard.quests.unsuccessfulQuest++
+		board.quests.unsuccessfulQuest++
 	}
-	if playerName, ok := globalBoard.CharacterToPlayer[KingArthur]; ok {
+	if playerName, ok := board.CharacterToPlayer[KingArthur]; ok {
 		//King-Arthur is playing
-		if vote, ok := globalBoard.quests.playerVotedForCurrent[playerName.Player]; ok {
+		if vote, ok := board.quests.playerVotedForCurrent[playerName.Player]; ok {
 			//King-Arthur was in this quest
 			log.Println("switch King-Arthur's \"Fail\" to \"Success")
 			realResults := make([]int, len(mp))
@@ -185,174 +178,108 @@ func EndJourney(res *QuestStats, mp []int, curEntry *QuestArchiveItem, current i
 					break
 				}
 			}
-			realFinal := CalculateQuestResult(realResults)
+			realFinal := board.CalculateQuestResult(realResults)
 			log.Println("Original quest result: ", res.Final, "actual quest result: ", realFinal)
 			if res.Final != realFinal {
-				globalBoard.quests.differentResults[current+1] = realFinal
+				board.quests.differentResults[current+1] = realFinal
 				if res.Final == JorneySuccess {
-					globalBoard.quests.successfulQuest--
-					globalBoard.quests.unsuccessfulQuest++
+					board.quests.successfulQuest--
+					board.quests.unsuccessfulQuest++
 				} else {
-					globalBoard.quests.successfulQuest++
-					globalBoard.quests.unsuccessfulQuest--
+					board.quests.successfulQuest++
+					board.quests.unsuccessfulQuest--
 				}
 			}
 		}
 	}
-	numOfExpectedQuests := globalConfigPerNumOfPlayers[globalBoard.numOfPlayers].NumOfQuests
-	if globalBoard.quests.successfulQuest > numOfExpectedQuests/2 {
-		pendingMurders, hasMurders := GetMurdersAfterGoodsWins()
+	board.afterQuest()
+	winner := board.scriptedWinner()
+	if winner == GOOD || (winner == "" && board.layout.isGoodVictory(board.quests.successfulQuest)) {
+		pendingMurders, hasMurders := board.GetMurdersAfterGoodsWins()
 		if !hasMurders {
-			globalBoard.State = VictoryForGood
-			globalBoard.StateDescription = "VICTORY for Goods"
+			board.setState(VictoryForGood)
+			board.StateDescription = "VICTORY for Goods"
 		} else {
 			fmt.Println(pendingMurders)
-			globalBoard.State = MurdersAfterGoodVictory
-			globalBoard.PendingMurders = pendingMurders
-
-			targetCharactersString := strings.Join(globalBoard.PendingMurders[0].TargetCharacters[:], ",")
-			globalBoard.StateDescription = "Murder: " + globalBoard.PendingMurders[0].ByCharacter + " is trying to kill: " +
-				targetCharactersString
-		}
-	} else if isBadVictory(globalBoard.quests.unsuccessfulQuest, numOfExpectedQuests) {
-		pendingMurders, hasMurders := GetMurdersAfterBadsWins()
-		if !hasMurders {
-			globalBoard.State = VictoryForBad
-			globalBoard.StateDescription = "VICTORY for Bads"
-		} else {
-			globalBoard.State = MurdersAfterBadVictory
-			globalBoard.PendingMurders = pendingMurders
+			board.setState(MurdersAfterGoodVictory)
+			board.PendingMurders = pendingMurders
 
-			targetCharactersString := strings.Join(globalBoard.PendingMurders[0].TargetCharacters[:], ",")
-			globalBoard.StateDescription = "Murders: " + globalBoard.PendingMurders[0].ByCharacter + " should kill: " +
+			targetCharactersString := strings.Join(board.PendingMurders[0].TargetCharacters[:], ",")
+			board.StateDescription = "Murder: " + board.PendingMurders[0].ByCharacter + " is trying to kill: " +
 				targetCharactersString
 		}
+	} else if winner == BAD || board.layout.isBadVictory(board.quests.unsuccessfulQuest) {
+		board.BadsWin()
 	} else { //game continue
-		if globalBoard.quests.Flags[HAS_TWO_LANCELOT] ||
-			globalBoard.quests.Flags[HAS_ONLY_BAD_LANCELOT] ||
-			globalBoard.quests.Flags[HAS_ONLY_GOOD_LANCELOT] {
-			//random number to decide if lancelots switch
-			isSwitchLancelots := globalBoard.lancelotCards[globalBoard.lancelotCardsIndex]
-			globalBoard.lancelotCardsIndex = (globalBoard.lancelotCardsIndex + 1) % len(globalBoard.lancelotCards)
-			if isSwitchLancelots == 1 {
-				if globalBoard.quests.Flags[HAS_TWO_LANCELOT] {
-					lanBad := globalBoard.CharacterToPlayer[LancelotBad]
-					lanGood := globalBoard.CharacterToPlayer[LancelotGood]
-
-
-					globalBoard.CharacterToPlayer[LancelotBad] = lanGood
-					globalBoard.CharacterToPlayer[LancelotGood] = lanBad
-					globalBoard.PlayerToCharacter[lanBad] = LancelotGood
-					globalBoard.PlayerToCharacter[lanGood] = LancelotBad
-					curEntry.IsSwitchLancelot = true
-
-					// If Lancelot-Bad was chosen to be the assassin, we need to update that.
-					assasinPlayer, _ := globalBoard.CharacterToPlayer[Assassin]
-					if assasinPlayer == lanBad {
-						globalBoard.CharacterToPlayer[Assassin] = lanGood
-					}
-					//fix bug of viviana that seeother lanselot
-					/*for i, pl := range globalBoard.PlayersWithBadCharacter {
-						if pl == lanBad.Player {
-							globalBoard.PlayersWithBadCharacter[i] = lanGood.Player
-							break
-						}
-					}
-					for i, pl := range globalBoard.playersWithGoodCharacter {
-						if pl == lanGood.Player {
-							globalBoard.playersWithGoodCharacter[i] = lanBad.Player
-							break
-						}
-					}*/
-				} else if globalBoard.quests.Flags[HAS_ONLY_BAD_LANCELOT] {
-					lanBad := globalBoard.CharacterToPlayer[LancelotBad]
-					globalBoard.CharacterToPlayer[LancelotGood] = lanBad
-					globalBoard.PlayerToCharacter[lanBad] = LancelotGood
-					delete(globalBoard.CharacterToPlayer, LancelotBad)
-					for i, ch := range globalBoard.Characters {
-						if ch == LancelotBad {
-							globalBoard.Characters = append(globalBoard.Characters[:i], globalBoard.Characters[i+1:]...)
-						}
-						globalBoard.Characters = append(globalBoard.Characters, LancelotGood)
-						break
-					}
-					curEntry.IsSwitchLancelot = true
-					delete(globalBoard.quests.Flags, HAS_ONLY_BAD_LANCELOT)
-					globalBoard.quests.Flags[HAS_ONLY_GOOD_LANCELOT] = true
-				} else if globalBoard.quests.Flags[HAS_ONLY_GOOD_LANCELOT] {
-					lanBad := globalBoard.CharacterToPlayer[LancelotGood]
-					globalBoard.CharacterToPlayer[LancelotBad] = lanBad
-					globalBoard.PlayerToCharacter[lanBad] = LancelotBad
-					delete(globalBoard.CharacterToPlayer, LancelotGood)
-					for i, ch := range globalBoard.Characters {
-						if ch == LancelotGood {
-							globalBoard.Characters = append(globalBoard.Characters[:i], globalBoard.Characters[i+1:]...)
-						}
-						globalBoard.Characters = append(globalBoard.Characters, LancelotBad)
-						break
-					}
-					curEntry.IsSwitchLancelot = true
-					delete(globalBoard.quests.Flags, HAS_ONLY_GOOD_LANCELOT)
-					globalBoard.quests.Flags[HAS_ONLY_BAD_LANCELOT] = true
-				}
-			}
-		}
+		board.drawLancelotCard(curEntry, current)
 		//end of special actions after quest
-		if globalBoard.quests.Flags[LADY] && globalBoard.quests.current >= 1 {
-			globalBoard.State = WaitingForLadySuggester
-			globalBoard.StateDescription = "Lady Of The Lake: " + globalBoard.ladyOfTheLake.currentSuggester +
+		if board.isLadyQuest(board.quests.current) {
+			board.setState(WaitingForLadySuggester)
+			board.StateDescription = "Lady Of The Lake: " + board.ladyOfTheLake.currentSuggester +
 				" is choosing player..."
 		} else {
-			globalBoard.State = WaitingForSuggestion
-			suggesterIndex := globalBoard.suggestions.suggesterIndex
-			globalBoard.StateDescription = "Suggestion For Next Quest: " + globalBoard.PlayerNames[suggesterIndex].Player +
+			board.setState(WaitingForSuggestion)
+			suggesterIndex := board.suggestions.suggesterIndex
+			board.StateDescription = "Suggestion For Next Quest: " + board.PlayerNames[suggesterIndex].Player +
 			" is choosing players..."
 		}
 
 	}
-	globalBoard.quests.playerVotedForCurrentQuest = make([]string, 0)
-	globalBoard.quests.playerVotedForCurrent = make(map[string]int)
-	globalBoard.votesForNextMission = make(map[string]bool) //for suggestions
-	globalBoard.suggestions.SuggestedPlayers = make([]string, 0)
-	globalBoard.suggestions.OnlyGoodSuggested = false
+	board.quests.playerVotedForCurrentQuest = make([]string, 0)
+	board.quests.playerVotedForCurrent = make(map[string]int)
+	board.votesForNextMission = make(map[string]bool) //for suggestions
+	board.suggestions.SuggestedPlayers = make([]string, 0)
+	board.suggestions.OnlyGoodSuggested = false
 }
 
-func isBadVictory(numOfUnsuccessfulQuests, numOfExpectedQuests int) bool {
-	return numOfUnsuccessfulQuests > numOfExpectedQuests/2 || (numOfExpectedQuests == 4 && numOfUnsuccessfulQuests == 2)
+func (board *BoardGame) BadsWin() {
+	pendingMurders, hasMurders := board.GetMurdersAfterBadsWins()
+	if !hasMurders {
+		board.setState(VictoryForBad)
+		board.StateDescription = "VICTORY for Bads"
+	} else {
+		board.setState(MurdersAfterBadVictory)
+		board.PendingMurders = pendingMurders
+
+		targetCharactersString := strings.Join(board.PendingMurders[0].TargetCharacters[:], ",")
+		board.StateDescription = "Murders: " + board.PendingMurders[0].ByCharacter + " should kill: " +
+			targetCharactersString
+	}
 }
 
-func CalculateQuestResult(mp []int) int {
+func (board *BoardGame) CalculateQuestResult(mp []int) int {
 	result := JorneySuccess
 	log.Println("++ last")
 	NumOfFailures := 0
 	NumOfReverse := 0
+	NumOfMagic := 0
 	for _, v := range mp {
-		if v == VoteFail {
+		if v == VoteFail || v == VoteRogueFail {
 			NumOfFailures++
 		}
 		if v == VoteReversal {
 			NumOfReverse++
 		}
+		if v == VoteMagic {
+			NumOfMagic++
+		}
 	}
 
-	questType := getTypeOfLevel(globalBoard.quests.current+1, globalBoard.numOfPlayers)
+	questType := board.layout.typeOfLevel(board.quests.current + 1)
+	failsRequired := board.layout.failsRequired(board.quests.current + 1)
 	if questType == FlushQuest {
 		if NumOfFailures == 1 {
 			result = JorneyFail
 		}
 	} else if questType == TwoFailsRequiredQuest {
 
-		if NumOfFailures >= 2 {
+		if NumOfFailures >= failsRequired {
 			result = JorneyFail
 		}
 		if NumOfReverse >= 1 {
-			if NumOfFailures == 0 {
-				result = JorneyFail
-			}
-			if NumOfFailures == 1 {
+			if NumOfFailures < failsRequired {
 				result = JorneyFail
-			}
-			if NumOfFailures >= 2 {
+			} else {
 				result = JorneySuccess
 			}
 			NumOfReverse--
@@ -362,8 +289,9 @@ func CalculateQuestResult(mp []int) int {
 			result = JorneyFail
 		}
 	}
-	if NumOfReverse > 0 {
-		if NumOfReverse%2 != 0 {
+	/* Every reversal left and every magic card flips the result of the other cards */
+	if NumOfReverse+NumOfMagic > 0 {
+		if (NumOfReverse+NumOfMagic)%2 != 0 {
 			if result == JorneyFail {
 				result = JorneySuccess
 			} else {
@@ -371,115 +299,131 @@ func CalculateQuestResult(mp []int) int {
 			}
 		}
 	}
+	for _, player := range board.suggestions.SuggestedPlayers {
+		if rules := characterRules(board.PlayerToCharacter[PlayerName{player}]); rules != nil && rules.QuestResultHook != nil {
+			result = rules.QuestResultHook(board, mp, result)
+		}
+	}
 	return result
 }
 
-func getOptionalVotesAccordingToQuestMembers(character string, questMembers map[string]bool,
-	flags map[int]bool, current int, numOfPlayers int) []string {
-
-	if character == "Gawain" {
-		return []string{"Fail", "Success"}
+// afterQuest runs the AfterQuest rules of the roles in the game, in seat order.
+func (board *BoardGame) afterQuest() {
+	for _, player := range board.PlayerNames {
+		if rules := characterRules(board.PlayerToCharacter[player]); rules != nil && rules.AfterQuest != nil {
+			rules.AfterQuest(board, player)
+		}
 	}
+}
 
-	if character == UtherPendragon {
-		return []string{"Empty"}
+// scriptedWinner asks the roles in the game, in seat order, whether the game ends. "" plays on.
+func (board *BoardGame) scriptedWinner() string {
+	for _, player := range board.PlayerNames {
+		if rules := characterRules(board.PlayerToCharacter[player]); rules != nil && rules.WinCheck != nil {
+			if winner := rules.WinCheck(board); winner != "" {
+				log.Println(board.PlayerToCharacter[player], "decided the game for", winner)
+				return winner
+			}
+		}
 	}
+	return ""
+}
 
-	if character == KingArthur {
-		return []string{"Fail"}
+func (board *BoardGame) getOptionalVotesAccordingToQuestMembers(character string, questMembers map[string]bool,
+	flags map[int]bool, current int, numOfPlayers int) []string {
+
+	rules := characterRules(character)
+	if rules == nil {
+		return []string{SUCCESS}
 	}
-	if character == LancelotBad {
-		return []string{"Fail"}
+	quest := QuestContext{Members: questMembers, Flags: flags, Current: current, NumOfPlayers: numOfPlayers,
+		Flush: FlushQuest == board.layout.typeOfLevel(current+1)}
+	if rules.QuestCardsHook != nil {
+		if cards := rules.QuestCardsHook(board, quest); cards != nil {
+			log.Println(character, " has", cards)
+			return cards
+		}
 	}
-	if character == Balin {
-		return []string{"Fail"}
+	cards := rules.QuestCards
+	if quest.Flush {
+		cards = rules.FlushCards
 	}
+	log.Println(character, " has", cards)
+	return append([]string{}, cards...)
+}
 
-	/*
-		Titanya's optional votes: If it's the first vote for Titanya - she must vote "Fail".
-		Afterward, she vote Success. If the bads needs one more failure for victory - Titanya
-		votes Success!
-	*/
-	if character == Titanya {
-		numOfExpectedQuests := globalConfigPerNumOfPlayers[numOfPlayers].NumOfQuests
-		if isBadVictory(globalBoard.quests.unsuccessfulQuest+1, numOfExpectedQuests) {
-			return []string{"Success"}
-		}
-		if _, ok := flags[TITANYA_FIRST_FAIL]; !ok {
-			log.Println("titanya has fail")
-			return []string{"Fail"}
-		}
+/*
+	Titanya's optional votes: If it's the first vote for Titanya - she must vote "Fail".
+	Afterward, she vote Success. If the bads needs one more failure for victory - Titanya
+	votes Success!
+*/
+func titanyaCards(board *BoardGame, quest QuestContext) []string {
+	if board.layout.isBadVictory(board.quests.unsuccessfulQuest + 1) {
+		return []string{SUCCESS}
 	}
-
-	if character == Elaine {
-		numOfExpectedQuests := globalConfigPerNumOfPlayers[numOfPlayers].NumOfQuests
-		if _, ok := flags[ELAINE_AVALON_POWER_CARD]; !ok && numOfExpectedQuests != globalBoard.quests.current+1 {
-			log.Println("elaine avalon card or success")
-			return []string{"Success", "Avalon Power"}
-		}
+	if _, ok := quest.Flags[TITANYA_FIRST_FAIL]; !ok {
+		return []string{FAIL}
 	}
+	return nil
+}
 
-	if character == Polygraph {
-		return []string{"Fail"}
-	}
-	if character == Agravain {
-		return []string{"Success"}
+// The Brute may fail only the first three quests.
+func bruteCards(board *BoardGame, quest QuestContext) []string {
+	if quest.Current >= 3 {
+		return []string{SUCCESS}
 	}
-	if character == Lot {
-		return []string{"Success"}
+	return nil
+}
+
+// The Revealer is shown to everyone once the second quest failed.
+func revealerAfterQuest(board *BoardGame, player PlayerName) {
+	if board.quests.unsuccessfulQuest >= 2 {
+		board.revealToAll(player.Player, Revealer)
 	}
-	if character == Nimue {
-		if _, ok := questMembers[Merlin]; ok {
-			if _, ok := questMembers[Galahad]; !ok {
-				log.Println("nimue  has fail")
-				return []string{"Fail"}
-			}
-		}
+}
+
+func titanyaPlayed(board *BoardGame, vote int) {
+	if vote == VoteFail {
+		board.quests.Flags[TITANYA_FIRST_FAIL] = true
 	}
+}
 
-	if FlushQuest == getTypeOfLevel(current+1, numOfPlayers) {
-		if _, ok := badCharacters[character]; ok || character == "Ginerva" || character == Meliagant {
-			if character == Mora {
-				return []string{"Fail", "Success"}
-			}
-			return []string{"Fail"}
-		} else {
-			return []string{"Success"}
-		}
+// Elaine may play her Avalon Power card once, not on the last quest.
+func elaineCards(board *BoardGame, quest QuestContext) []string {
+	if _, ok := quest.Flags[ELAINE_AVALON_POWER_CARD]; !ok && board.layout.NumOfQuests != quest.Current+1 {
+		return []string{SUCCESS, AVALON_POWER}
 	}
+	return nil
+}
 
-	if character == Meliagant {
-		return []string{"Fail", "Success"}
+func elainePlayed(board *BoardGame, vote int) {
+	if vote == VoteAvalonPower {
+		board.quests.Flags[ELAINE_AVALON_POWER_CARD] = true
 	}
+}
 
-	if character == TheQuestingBeast {
-		if _, ok := flags[BEAST_FIRST_SUCCESS]; !ok {
-			return []string{"Success", "Beast"}
-		} else {
-			return []string{"Beast"}
+// Nimue must fail a quest with Merlin on it, unless Galahad is there too.
+func nimueCards(board *BoardGame, quest QuestContext) []string {
+	if _, ok := quest.Members[Merlin]; ok {
+		if _, ok := quest.Members[Galahad]; !ok {
+			return []string{FAIL}
 		}
 	}
+	return nil
+}
 
-	res := make([]string, 0)
-	if character == BadAngel || character == GoodAngel {
-		res = append(res, "Reversal")
+// The Beast plays Success once, then only Beast cards.
+func beastCards(board *BoardGame, quest QuestContext) []string {
+	if _, ok := quest.Flags[BEAST_FIRST_SUCCESS]; ok && !quest.Flush {
+		return []string{BEAST}
 	}
+	return nil
+}
 
-	res = append(res, "Success")
-
-	p, ok := globalBoard.CharacterToPlayer[character]
-	var isStray bool
-	if ok {
-		char, _ := globalBoard.PlayerToCharacter[p]
-		if char == Stray {
-			isStray = true
-		}
-	}
-	if badCharacters[character] || character == "Puck" || character == "Ginerva" || isStray {
-		res = append(res, "Fail")
+func beastPlayed(board *BoardGame, vote int) {
+	if vote == VoteSuccess {
+		board.quests.Flags[BEAST_FIRST_SUCCESS] = true
 	}
-	log.Println(character, " has", res)
-	return res
 }
 
 func getVoteStr(vote int) string {
@@ -501,5 +445,14 @@ func getVoteStr(vote int) string {
 	if VoteEmpty == vote {
 		return "Empty"
 	}
+	if VoteMagic == vote {
+		return MAGIC
+	}
+	if VoteRogueSuccess == vote {
+		return ROGUE_SUCCESS
+	}
+	if VoteRogueFail == vote {
+		return ROGUE_FAIL
+	}
 	return "N/A"
 }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+)
+
+const ( // seat connection status
+	SeatConnected    = "connected"
+	SeatDisconnected = "disconnected"
+	SeatReconnected  = "reconnected"
+	SeatIdle         = "idle"
+)
+
+type SeatConnection struct {
+	Status   string `json:"status"`
+	LastSeen int64  `json:"lastSeen,omitempty"` //unix milliseconds
+}
+
+// maxMissedMessages bounds the broadcasts kept for a disconnected seat.
+const maxMissedMessages = 200
+
+type sequencedMessage struct {
+	seq     int64
+	message []byte
+}
+
+type ResumeResponse struct {
+	Type    string        `json:"ty"`
+	Room    string        `json:"room"`
+	Player  string        `json:"player"`
+	LastSeq int64         `json:"lastSeq"`
+	Seq     int64         `json:"seq"`
+	Missed  int           `json:"missed"`
+	Secrets PlayerSecrets `json:"playerSecrets"`
+}
+
+func (manager *ClientManager) keepMissed(clientId string, seq int64, message []byte) {
+	missed := append(manager.missed[clientId], sequencedMessage{seq: seq, message: message})
+	if len(missed) > maxMissedMessages {
+		missed = missed[len(missed)-maxMissedMessages:]
+	}
+	manager.missed[clientId] = missed
+}
+
+// resume gives a returning player the seat's secrets and replays the broadcasts sent after conn.lastSeq.
+func (manager *ClientManager) resume(conn *Client) {
+	missed := manager.missed[conn.id]
+	delete(manager.missed, conn.id)
+
+	replay := make([][]byte, 0, len(missed))
+	for _, m := range missed {
+		if m.seq > conn.lastSeq {
+			replay = append(replay, m.message)
+		}
+	}
+
+	response := ResumeResponse{Type: "resume", Room: manager.board.name, Player: conn.id,
+		LastSeq: conn.lastSeq, Seq: manager.seq, Missed: len(replay)}
+	manager.board.mutex.RLock()
+	if secrets, ok := manager.board.SecretsMap[conn.id]; ok && secrets != nil {
+		response.Secrets = *secrets
+	}
+	manager.board.mutex.RUnlock()
+
+	log.Println("resume seat of", conn.id, "in room", manager.board.name, ". replaying", len(replay), "messages")
+	jsonMessage, _ := json.Marshal(&response)
+	conn.send.pushReplay(append([][]byte{jsonMessage}, replay...))
+}
+
+// replaceStaleClients drops older sockets of the same user, e.g. a phone that reconnected before
+// the old socket timed out, and tells whether there was one. The seat stays with the new socket.
+func (manager *ClientManager) replaceStaleClients(conn *Client) bool {
+	replaced := false
+	for other := range manager.clients {
+		if other != conn && other.id == conn.id {
+			log.Println("replacing stale socket of", conn.id)
+			delete(manager.clients, other)
+			other.send.close()
+			replaced = true
+		}
+	}
+	return replaced
+}
+
+// dropDisconnectedSeats frees the seats of players that dropped during the game and never came back.
+// The lock must be held.
+func (board *BoardGame) dropDisconnectedSeats() {
+	seated := make([]PlayerName, 0, len(board.PlayerNames))
+	for _, p := range board.PlayerNames {
+		if board.connections[p.Player].Status == SeatDisconnected {
+			log.Println(p.Player, "never came back. freeing the seat")
+			delete(board.connections, p.Player)
+			continue
+		}
+		seated = append(seated, p)
+	}
+	board.PlayerNames = seated
+}
+
+// releaseGoneSeats frees the seats of players that never came back once the game is over, and forgets the
+// broadcasts kept for players that are no longer seated.
+func (manager *ClientManager) releaseGoneSeats() {
+	manager.board.mutex.Lock()
+	defer manager.board.mutex.Unlock()
+	if manager.board.isGameOver() {
+		manager.board.dropDisconnectedSeats()
+	}
+	for id := range manager.missed {
+		if !manager.board.isSeated(id) {
+			delete(manager.missed, id)
+		}
+	}
+}
+
+// notifySeatDisconnected lets the table see that a seated player dropped during the game.
+func (manager *ClientManager) notifySeatDisconnected(conn *Client) {
+	if _, ok := manager.missed[conn.id]; !ok {
+		return
+	}
+	jsonMessage, _ := json.Marshal(&Message{Sender: conn.id, Content: "board"})
+	manager.handleBroadcast(jsonMessage)
+}
+
+// FindSeat returns the room where the player holds a seat in a running game.
+func (lobby *Lobby) FindSeat(player string) (*BoardGame, bool) {
+	lobby.mutex.RLock()
+	defer lobby.mutex.RUnlock()
+	for _, room := range lobby.rooms {
+		room.mutex.RLock()
+		inGame := room.State != NotStarted && !room.isGameOver()
+		seated := SliceIndex(len(room.PlayerNames), func(i int) bool { return room.PlayerNames[i].Player == player }) >= 0
+		room.mutex.RUnlock()
+		if inGame && seated {
+			return room, true
+		}
+	}
+	return nil, false
+}
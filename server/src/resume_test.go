@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_Resume(t *testing.T) {
+	t.Run("Keeps the seat of a player that drops mid-game", should_keep_seat_when_player_drops_mid_game)
+	t.Run("Replays broadcasts missed after the last sequence number", should_replay_missed_broadcasts)
+	t.Run("Frees the seat of a player that never came back on reset", should_free_gone_seat_on_reset)
+	t.Run("Frees the seat of a player that never came back once the game is over", should_free_gone_seat_after_game)
+	t.Run("Resumes a seat whose live socket was replaced", should_resume_replaced_socket)
+}
+
+func should_keep_seat_when_player_drops_mid_game(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin)
+	alice := &Client{id: "alice", send: newSendQueue(10), patch: newPatchState(false)}
+	bob := &Client{id: "bob", send: newSendQueue(10), patch: newPatchState(false)}
+	board.manager.clients[alice] = true
+	board.manager.clients[bob] = true
+
+	//Act
+	board.manager.removeClient(alice)
+
+	//Assert
+	if len(board.PlayerNames) != 2 {
+		t.Error("Seat should be kept during the game, got", board.PlayerNames)
+	}
+	if board.connections["alice"].Status != SeatDisconnected {
+		t.Error("Seat should be marked disconnected, got", board.connections["alice"].Status)
+	}
+	if _, ok := board.manager.missed["alice"]; !ok {
+		t.Error("Broadcasts should be kept for the disconnected seat")
+	}
+}
+
+func should_replay_missed_broadcasts(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin)
+	board.manager.missed["alice"] = make([]sequencedMessage, 0)
+	for i := 0; i < 3; i++ {
+		jsonMessage, _ := json.Marshal(&Message{Sender: "bob", Content: "board"})
+		board.manager.handleBroadcast(jsonMessage)
+	}
+	alice := &Client{id: "alice", send: newSendQueue(10), patch: newPatchState(false), lastSeq: 1}
+
+	//Act
+	board.manager.resume(alice)
+
+	//Assert
+	var response ResumeResponse
+	message, _ := alice.send.pop()
+	json.Unmarshal(message, &response)
+	if response.Type != "resume" || response.Seq != 3 || response.Missed != 2 {
+		t.Error("Unexpected resume response:", response)
+	}
+	for _, expectedSeq := range []int64{2, 3} {
+		var msg Message
+		message, _ := alice.send.pop()
+		json.Unmarshal(message, &msg)
+		if msg.Seq != expectedSeq {
+			t.Error("Expected replayed message", expectedSeq, "got", msg.Seq)
+		}
+	}
+	if _, ok := board.manager.missed["alice"]; ok {
+		t.Error("Missed broadcasts should be dropped after resuming")
+	}
+}
+
+func dropAliceMidGame(t *testing.T) *BoardGame {
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin)
+	alice := &Client{id: "alice", send: newSendQueue(10), patch: newPatchState(false)}
+	bob := &Client{id: "bob", send: newSendQueue(10), patch: newPatchState(false)}
+	board.manager.clients[alice] = true
+	board.manager.clients[bob] = true
+	board.connections["bob"] = SeatConnection{Status: SeatConnected}
+	board.manager.removeClient(alice)
+	return board
+}
+
+func should_free_gone_seat_on_reset(t *testing.T) {
+	//Arrange
+	board := dropAliceMidGame(t)
+
+	//Act
+	board.Reset()
+	jsonMessage, _ := json.Marshal(&Message{Sender: "bob", Content: "board"})
+	board.manager.handleBroadcast(jsonMessage)
+
+	//Assert
+	if !reflect.DeepEqual(board.PlayerNames, []PlayerName{{"bob"}}) {
+		t.Error("Only bob should stay seated, got", board.PlayerNames)
+	}
+	if _, ok := board.connections["alice"]; ok {
+		t.Error("The connection of alice should be forgotten")
+	}
+	if _, ok := board.manager.missed["alice"]; ok {
+		t.Error("Broadcasts should not be kept for alice anymore")
+	}
+}
+
+func should_free_gone_seat_after_game(t *testing.T) {
+	//Arrange
+	board := dropAliceMidGame(t)
+	assassin := playerOf(board, Assassin)
+	for board.State == WaitingForSuggestion {
+		playQuest(t, board, []string{"alice", "bob"}, assassin)
+	}
+
+	//Act
+	jsonMessage, _ := json.Marshal(&Message{Sender: "bob", Content: "board"})
+	board.manager.handleBroadcast(jsonMessage)
+
+	//Assert
+	if !reflect.DeepEqual(board.PlayerNames, []PlayerName{{"bob"}}) {
+		t.Error("Only bob should stay seated, got", board.PlayerNames)
+	}
+	if _, ok := board.manager.missed["alice"]; ok {
+		t.Error("Broadcasts should not be kept for alice anymore")
+	}
+}
+
+func should_resume_replaced_socket(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin)
+	merlin := playerOf(board, Merlin)
+	old := &Client{id: merlin, send: newSendQueue(10), patch: newPatchState(false)}
+	board.manager.clients[old] = true
+	board.manager.seq = 4
+	phone := &Client{id: merlin, send: newSendQueue(10), patch: newPatchState(false)}
+
+	//Act
+	board.manager.registerClient(phone)
+
+	//Assert
+	if board.manager.clients[old] || !old.send.isClosed() {
+		t.Error("The old socket should be dropped")
+	}
+	if board.connections[merlin].Status != SeatReconnected {
+		t.Error("The seat should be resumed, got", board.connections[merlin].Status)
+	}
+	var response ResumeResponse
+	message, _ := phone.send.pop()
+	json.Unmarshal(message, &response)
+	if response.Type != "resume" || response.Player != merlin || response.Seq != 4 ||
+		!reflect.DeepEqual(response.Secrets.PlayersWithBadCharacter, []string{playerOf(board, Assassin)}) {
+		t.Error("Unexpected resume response:", response)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"log"
+	"strconv"
+	"strings"
+)
+
+// rogue is the card a Rogue holds once a game, and the quest result it needs to win with it.
+type rogue struct {
+	card   string
+	vote   int
+	flag   int
+	winsOn int
+}
+
+var rogues = map[string]rogue{
+	GoodRogue: {card: ROGUE_SUCCESS, vote: VoteRogueSuccess, flag: GOOD_ROGUE_CARD_PLAYED, winsOn: JorneySuccess},
+	EvilRogue: {card: ROGUE_FAIL, vote: VoteRogueFail, flag: EVIL_ROGUE_CARD_PLAYED, winsOn: JorneyFail},
+}
+
+// IndividualWin is a player that wins on their own, whichever side won the game.
+type IndividualWin struct {
+	Player    string `json:"player"`
+	Character string `json:"character"`
+	Quest     int    `json:"quest"` // 1 based
+	Reason    string `json:"reason"`
+}
+
+// rogueCards offers the Rogue card with the role's other cards, until it is played.
+func rogueCards(character string) func(board *BoardGame, quest QuestContext) []string {
+	return func(board *BoardGame, quest QuestContext) []string {
+		r := rogues[character]
+		if quest.Flags[r.flag] {
+			return nil
+		}
+		rules := characterRules(character)
+		cards := rules.QuestCards
+		if quest.Flush {
+			cards = rules.FlushCards
+		}
+		return append([]string{r.card}, cards...)
+	}
+}
+
+func roguePlayed(character string) func(board *BoardGame, vote int) {
+	return func(board *BoardGame, vote int) {
+		if r := rogues[character]; vote == r.vote {
+			board.quests.Flags[r.flag] = true
+		}
+	}
+}
+
+// rogueAfterQuest makes the Rogue a winner when their card is still on a quest that ended the way it needs.
+func rogueAfterQuest(board *BoardGame, player PlayerName) {
+	character := board.PlayerToCharacter[player]
+	r := rogues[character]
+	if vote, ok := board.quests.playerVotedForCurrent[player.Player]; !ok || vote != r.vote {
+		return
+	}
+	quest := board.quests.current + 1
+	if board.quests.results[quest].Final != r.winsOn {
+		log.Println(character, "played", r.card, "but quest", quest, "did not end as needed")
+		return
+	}
+	log.Println(character, "wins with", r.card, "on quest", quest)
+	board.individualWins = append(board.individualWins, IndividualWin{Player: player.Player, Character: character,
+		Quest: quest, Reason: r.card + " on quest " + strconv.Itoa(quest)})
+}
+
+// announceIndividualWins adds the individual winners to the description of a finished game.
+func (board *BoardGame) announceIndividualWins(description string) string {
+	if !board.isGameOver() || len(board.individualWins) == 0 {
+		return description
+	}
+	winners := make([]string, 0, len(board.individualWins))
+	for _, win := range board.individualWins {
+		winners = append(winners, win.Player+" ("+win.Character+")")
+	}
+	return description + "; also winning: " + strings.Join(winners, ",")
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"sort"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+/*
+RoleScript is a Starlark script attached to a custom role. It may define:
+
+	vote_options(quest)          the cards the role may play, or None for the role's cards
+	quest_result(quest, cards, result)  "Success"/"Fail" to change the result of a quest the role is on, or None
+	win_check(quest)             "Good"/"Bad" to end the game after a quest, or None to play on
+
+Scripts cannot load modules or reach the server, and every call has a step budget.
+The quest argument is a read only view of the game (see questView).
+*/
+type RoleScript struct {
+	character   string
+	voteOptions starlark.Callable
+	questResult starlark.Callable
+	winCheck    starlark.Callable
+}
+
+const scriptMaxSteps = 100000
+
+var flagNames = map[int]string{
+	TITANYA_FIRST_FAIL:                "TITANYA_FIRST_FAIL",
+	BEAST_FIRST_SUCCESS:               "BEAST_FIRST_SUCCESS",
+	HAS_TWO_LANCELOT:                  "HAS_TWO_LANCELOT",
+	HAS_BALAIN_AND_BALIN:              "HAS_BALAIN_AND_BALIN",
+	HAS_ONLY_GOOD_LANCELOT:            "HAS_ONLY_GOOD_LANCELOT",
+	HAS_ONLY_BAD_LANCELOT:             "HAS_ONLY_BAD_LANCELOT",
+	EXCALIBUR:                         "EXCALIBUR",
+	ELAINE_AVALON_POWER_CARD:          "ELAINE_AVALON_POWER_CARD",
+	LADY:                              "LADY",
+	BEAST_VOTE_SEEN:                   "BEAST_VOTE_SEEN",
+	BEAST_AND_PELLINORE_AT_SAME_QUEST: "BEAST_AND_PELLINORE_AT_SAME_QUEST",
+	GOOD_ROGUE_CARD_PLAYED:            "GOOD_ROGUE_CARD_PLAYED",
+	EVIL_ROGUE_CARD_PLAYED:            "EVIL_ROGUE_CARD_PLAYED",
+}
+
+var scriptPredeclared = starlark.StringDict{
+	"SUCCESS":       starlark.String(SUCCESS),
+	"FAIL":          starlark.String(FAIL),
+	"REVERSAL":      starlark.String(REVERSAL),
+	"BEAST":         starlark.String(BEAST),
+	"EMPTY":         starlark.String(EMPTY),
+	"AVALON_POWER":  starlark.String(AVALON_POWER),
+	"MAGIC":         starlark.String(MAGIC),
+	"ROGUE_SUCCESS": starlark.String(ROGUE_SUCCESS),
+	"ROGUE_FAIL":    starlark.String(ROGUE_FAIL),
+	"GOOD":          starlark.String(GOOD),
+	"BAD":           starlark.String(BAD),
+}
+
+func newScriptThread(character string) *starlark.Thread {
+	thread := &starlark.Thread{Name: character, Print: func(_ *starlark.Thread, msg string) {
+		log.Println("script", character+":", msg)
+	}}
+	thread.SetMaxExecutionSteps(scriptMaxSteps)
+	return thread
+}
+
+func compileRoleScript(character string, source string) (*RoleScript, error) {
+	globals, err := starlark.ExecFile(newScriptThread(character), character+".star", source, scriptPredeclared)
+	if err != nil {
+		return nil, err
+	}
+	script := &RoleScript{character: character}
+	hooks := map[string]*starlark.Callable{"vote_options": &script.voteOptions, "quest_result": &script.questResult,
+		"win_check": &script.winCheck}
+	for name, hook := range hooks {
+		value, ok := globals[name]
+		if !ok {
+			continue
+		}
+		if *hook, ok = value.(starlark.Callable); !ok {
+			return nil, errors.New(name + " is not a function")
+		}
+	}
+	if script.voteOptions == nil && script.questResult == nil && script.winCheck == nil {
+		return nil, errors.New("the script defines none of vote_options, quest_result and win_check")
+	}
+	return script, nil
+}
+
+// call runs one hook. A failing script is logged and treated as returning None.
+func (script *RoleScript) call(hook starlark.Callable, args ...starlark.Value) starlark.Value {
+	result, err := starlark.Call(newScriptThread(script.character), hook, args, nil)
+	if err != nil {
+		log.Println("script", script.character, "failed:", err)
+		return starlark.None
+	}
+	return result
+}
+
+// attach sets the rules hooks for the functions the script defines.
+func (script *RoleScript) attach(rules *CharacterRules) {
+	if script.voteOptions != nil {
+		rules.QuestCardsHook = script.cards
+	}
+	if script.questResult != nil {
+		rules.QuestResultHook = script.result
+	}
+	if script.winCheck != nil {
+		rules.WinCheck = script.winner
+	}
+}
+
+func (script *RoleScript) cards(board *BoardGame, quest QuestContext) []string {
+	value := script.call(script.voteOptions, board.questView(script.character, quest))
+	if value == starlark.None {
+		return nil
+	}
+	iterable, ok := value.(starlark.Iterable)
+	if !ok {
+		log.Println("script", script.character, "vote_options should return a list, got", value.Type())
+		return nil
+	}
+	cards := make([]string, 0)
+	iter := iterable.Iterate()
+	defer iter.Done()
+	var card starlark.Value
+	for iter.Next(&card) {
+		name, ok := starlark.AsString(card)
+		if !ok || !questCardNames[name] {
+			log.Println("script", script.character, "vote_options returned an unknown card", card)
+			return nil
+		}
+		cards = append(cards, name)
+	}
+	return cards
+}
+
+func (script *RoleScript) result(board *BoardGame, votes []int, result int) int {
+	cards := make([]starlark.Value, 0, len(votes))
+	for _, vote := range votes {
+		cards = append(cards, starlark.String(getVoteStr(vote)))
+	}
+	value := script.call(script.questResult, board.questView(script.character, board.currentQuestContext()),
+		starlark.Tuple(cards), starlark.String(getResultStr(result)))
+	switch value {
+	case starlark.String(SUCCESS):
+		return JorneySuccess
+	case starlark.String(FAIL):
+		return JorneyFail
+	case starlark.None:
+	default:
+		log.Println("script", script.character, "quest_result should return Success, Fail or None, got", value)
+	}
+	return result
+}
+
+func (script *RoleScript) winner(board *BoardGame) string {
+	value := script.call(script.winCheck, board.questView(script.character, board.currentQuestContext()))
+	switch value {
+	case starlark.String(GOOD):
+		return GOOD
+	case starlark.String(BAD):
+		return BAD
+	case starlark.None:
+	default:
+		log.Println("script", script.character, "win_check should return Good, Bad or None, got", value)
+	}
+	return ""
+}
+
+func getResultStr(result int) string {
+	if result == JorneyFail {
+		return FAIL
+	}
+	return SUCCESS
+}
+
+func (board *BoardGame) currentQuestContext() QuestContext {
+	return QuestContext{Members: board.suggestions.SuggestedCharacters, Flags: board.quests.Flags,
+		Current: board.quests.current, NumOfPlayers: board.numOfPlayers,
+		Flush: FlushQuest == board.layout.typeOfLevel(board.quests.current+1)}
+}
+
+/*
+questView is what a script sees. Every value is immutable:
+
+	character, quest (1 based), num_of_players, expected_quests, flush, two_fails_required, fails_required,
+	members (characters on the quest), flags (flag name -> True), successes, failures,
+	results (Success/Fail of the finished quests)
+*/
+func (board *BoardGame) questView(character string, quest QuestContext) starlark.Value {
+	members := make([]string, 0, len(quest.Members))
+	for member, on := range quest.Members {
+		if on {
+			members = append(members, member)
+		}
+	}
+	sort.Strings(members)
+	memberValues := make(starlark.Tuple, 0, len(members))
+	for _, member := range members {
+		memberValues = append(memberValues, starlark.String(member))
+	}
+
+	flags := starlark.NewDict(len(quest.Flags))
+	for flag, on := range quest.Flags {
+		if name, ok := flagNames[flag]; ok && on {
+			flags.SetKey(starlark.String(name), starlark.True)
+		}
+	}
+	flags.Freeze()
+
+	results := make(starlark.Tuple, 0)
+	for level := 1; board.quests.results[level].Final != 0; level++ {
+		results = append(results, starlark.String(getResultStr(board.quests.results[level].Final)))
+	}
+
+	return starlarkstruct.FromStringDict(starlark.String("quest"), starlark.StringDict{
+		"character":          starlark.String(character),
+		"quest":              starlark.MakeInt(quest.Current + 1),
+		"num_of_players":     starlark.MakeInt(quest.NumOfPlayers),
+		"expected_quests":    starlark.MakeInt(board.layout.NumOfQuests),
+		"flush":              starlark.Bool(quest.Flush),
+		"two_fails_required": starlark.Bool(TwoFailsRequiredQuest == board.layout.typeOfLevel(quest.Current+1)),
+		"fails_required":     starlark.MakeInt(board.layout.failsRequired(quest.Current + 1)),
+		"members":            memberValues,
+		"flags":              flags,
+		"successes":          starlark.MakeInt(board.quests.successfulQuest),
+		"failures":           starlark.MakeInt(board.quests.unsuccessfulQuest),
+		"results":            results,
+	})
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_RoleScripts(t *testing.T) {
+	t.Run("Offers the cards a script returns", should_offer_scripted_cards)
+	t.Run("Lets a script change the result of its quest", should_let_script_change_quest_result)
+	t.Run("Falls back to the role's cards when a script runs too long", should_stop_runaway_script)
+	t.Run("Does not let a script change the flags", should_keep_flags_read_only)
+}
+
+func registerScriptedRole(t *testing.T, name string, script string) {
+	err := registerCustomRoles([]CustomRole{{Name: name, Script: script,
+		CharacterDescription: CharacterDescription{Loyalty: GOOD, CanVote: []string{SUCCESS}}}})
+	if err != nil {
+		t.Fatal("Role should register, got", err)
+	}
+}
+
+func should_offer_scripted_cards(t *testing.T) {
+	//Arrange
+	registerScriptedRole(t, "Test-Fairy", `
+def vote_options(quest):
+    if "Merlin" in quest.members and "TITANYA_FIRST_FAIL" not in quest.flags:
+        return [FAIL]
+    return None
+`)
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+
+	//Act
+	withMerlin := board.getOptionalVotesAccordingToQuestMembers("Test-Fairy", map[string]bool{Merlin: true}, map[int]bool{}, 0, 5)
+	afterFail := board.getOptionalVotesAccordingToQuestMembers("Test-Fairy", map[string]bool{Merlin: true},
+		map[int]bool{TITANYA_FIRST_FAIL: true}, 0, 5)
+
+	//Assert
+	if !reflect.DeepEqual(withMerlin, []string{FAIL}) || !reflect.DeepEqual(afterFail, []string{SUCCESS}) {
+		t.Error("Expected the scripted cards, then the role's cards, got", withMerlin, afterFail)
+	}
+}
+
+func should_let_script_change_quest_result(t *testing.T) {
+	//Arrange
+	registerScriptedRole(t, "Test-Saint", `
+def quest_result(quest, cards, result):
+    if len([c for c in cards if c == FAIL]) == 1:
+        return SUCCESS
+`)
+	board := startTestGame(t, GameConfiguration{}, Merlin, Assassin, "Test-Saint", Morgana, LoyalServentOfArthur)
+	assassin, morgana, saint := playerOf(board, Assassin), playerOf(board, Morgana), playerOf(board, "Test-Saint")
+
+	//Act
+	playQuest(t, board, []string{assassin, saint}, assassin)
+	playQuest(t, board, []string{assassin, morgana, saint}, assassin, morgana)
+	oneFail, twoFails := board.quests.results[1].Final, board.quests.results[2].Final
+
+	//Assert
+	if oneFail != JorneySuccess || twoFails != JorneyFail {
+		t.Error("A single fail should be forgiven, got", oneFail, twoFails)
+	}
+}
+
+func should_stop_runaway_script(t *testing.T) {
+	//Arrange
+	registerScriptedRole(t, "Test-Loop", `
+def vote_options(quest):
+    n = 0
+    for i in range(100000000):
+        n += i
+    return [FAIL]
+`)
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+
+	//Act
+	cards := board.getOptionalVotesAccordingToQuestMembers("Test-Loop", map[string]bool{}, map[int]bool{}, 0, 5)
+
+	//Assert
+	if !reflect.DeepEqual(cards, []string{SUCCESS}) {
+		t.Error("Expected the role's cards, got", cards)
+	}
+}
+
+func should_keep_flags_read_only(t *testing.T) {
+	//Arrange
+	registerScriptedRole(t, "Test-Thief", `
+def vote_options(quest):
+    quest.flags["LADY"] = True
+    return [FAIL]
+`)
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+	flags := map[int]bool{}
+
+	//Act
+	cards := board.getOptionalVotesAccordingToQuestMembers("Test-Thief", map[string]bool{}, flags, 0, 5)
+
+	//Assert
+	if !reflect.DeepEqual(cards, []string{SUCCESS}) || len(flags) != 0 {
+		t.Error("The script should fail without changing flags, got", cards, flags)
+	}
+}
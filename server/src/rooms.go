@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var defaultRoomName = getEnv("DEFAULT_ROOM", "main")
+
+type RoomInfo struct {
+	Name             string `json:"name"`
+	Players          int    `json:"players"`
+	State            int    `json:"state"`
+	StateDescription string `json:"stateDescription,omitempty"`
+	NotStarted       bool   `json:"notStarted"`
+	Sends            sendStats `json:"sends"`
+}
+
+type RoomListResponse struct {
+	Type    string     `json:"ty"`
+	Current string     `json:"current,omitempty"`
+	Rooms   []RoomInfo `json:"rooms"`
+}
+
+type Lobby struct {
+	mutex sync.RWMutex
+	rooms map[string]*BoardGame
+}
+
+var lobby = Lobby{rooms: make(map[string]*BoardGame)}
+
+// newRoom creates a board with its own lock and client manager and starts the manager thread.
+func newRoom(name string) *BoardGame {
+	manager := &ClientManager{
+		broadcast:  make(chan []byte),
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		leave:      make(chan *Client),
+		clients:    make(map[*Client]bool),
+		missed:     make(map[string][]sequencedMessage),
+	}
+	board := newBoardGame(name, &sync.RWMutex{}, manager)
+	manager.board = &board
+	go manager.start()
+	return &board
+}
+
+func (lobby *Lobby) CreateRoom(name string) (*BoardGame, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("room name is empty")
+	}
+	lobby.mutex.Lock()
+	defer lobby.mutex.Unlock()
+	if _, ok := lobby.rooms[name]; ok {
+		return nil, errors.New("room " + name + " already exists")
+	}
+	room := newRoom(name)
+	lobby.rooms[name] = room
+	log.Println("room created:", name)
+	return room, nil
+}
+
+func (lobby *Lobby) GetRoom(name string) (*BoardGame, bool) {
+	lobby.mutex.RLock()
+	defer lobby.mutex.RUnlock()
+	room, ok := lobby.rooms[name]
+	return room, ok
+}
+
+// GetOrCreateRoom is used for sockets that ask for a room on connection.
+func (lobby *Lobby) GetOrCreateRoom(name string) (*BoardGame, error) {
+	if room, ok := lobby.GetRoom(name); ok {
+		return room, nil
+	}
+	room, err := lobby.CreateRoom(name)
+	if err != nil {
+		if room, ok := lobby.GetRoom(name); ok {
+			return room, nil
+		}
+	}
+	return room, err
+}
+
+func (lobby *Lobby) ListRooms() []RoomInfo {
+	lobby.mutex.RLock()
+	rooms := make([]*BoardGame, 0, len(lobby.rooms))
+	for _, room := range lobby.rooms {
+		rooms = append(rooms, room)
+	}
+	lobby.mutex.RUnlock()
+
+	list := make([]RoomInfo, 0, len(rooms))
+	for _, room := range rooms {
+		list = append(list, room.Info())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func (board *BoardGame) Info() RoomInfo {
+	board.mutex.RLock()
+	defer board.mutex.RUnlock()
+	return RoomInfo{
+		Name:             board.name,
+		Players:          len(board.PlayerNames),
+		State:            board.State,
+		StateDescription: board.StateDescription,
+		NotStarted:       board.State == NotStarted,
+		Sends:            board.manager.Stats(),
+	}
+}
+
+// Reset starts a new game in this room only. Seated players and connections are kept, except for players
+// that dropped during the game and never came back.
+func (board *BoardGame) Reset() {
+	board.mutex.Lock()
+	board.dropDisconnectedSeats()
+	playerNames := board.PlayerNames
+	clientIdToPlayerName := board.clientIdToPlayerName
+	connections := board.connections
+	commandMutex := board.commandMutex
+	*board = newBoardGame(board.name, board.mutex, board.manager)
+	board.commandMutex = commandMutex
+	board.PlayerNames = playerNames
+	board.clientIdToPlayerName = clientIdToPlayerName
+	board.connections = connections
+	board.mutex.Unlock()
+}
+
+func (c *Client) sendRoomList() {
+	current := ""
+	if c.room != nil {
+		current = c.room.name
+	}
+	msg, _ := json.Marshal(&RoomListResponse{Type: "rooms", Current: current, Rooms: lobby.ListRooms()})
+	c.send.push(msg, false)
+}
+
+// joinRoom moves the client from its current room (if any) to the given room.
+func (c *Client) joinRoom(room *BoardGame) {
+	if c.room == room {
+		return
+	}
+	c.leaveRoom()
+	c.room = room
+	room.manager.register <- c
+	log.Println("client", c.id, "joined room", room.name)
+}
+
+func (c *Client) leaveRoom() {
+	if c.room == nil {
+		return
+	}
+	room := c.room
+	room.manager.leave <- c
+	c.room = nil
+	jsonMessage, _ := json.Marshal(&Message{Sender: c.id, Content: "board"})
+	room.manager.broadcast <- jsonMessage
+	log.Println("client", c.id, "left room", room.name)
+}
+
+func handleListRooms(c *Client, board *BoardGame, envelope *Envelope) error {
+	c.sendRoomList()
+	return nil
+}
+
+func handleCreateRoom(c *Client, board *BoardGame, envelope *Envelope) error {
+	var name string
+	if err := envelope.decode(&name); err != nil {
+		return err
+	}
+	room, err := lobby.CreateRoom(name)
+	if err != nil {
+		return newCommandError(ErrInvalidRoom, err.Error())
+	}
+	c.joinRoom(room)
+	c.sendRoomList()
+	return nil
+}
+
+func handleJoinRoom(c *Client, board *BoardGame, envelope *Envelope) error {
+	var name string
+	if err := envelope.decode(&name); err != nil {
+		return err
+	}
+	room, ok := lobby.GetRoom(name)
+	if !ok {
+		return newCommandError(ErrInvalidRoom, "no room "+name)
+	}
+	c.joinRoom(room)
+	c.sendRoomList()
+	return nil
+}
+
+func handleLeaveRoom(c *Client, board *BoardGame, envelope *Envelope) error {
+	c.leaveRoom()
+	c.sendRoomList()
+	return nil
+}
+
+func roomsPage(res http.ResponseWriter, req *http.Request) {
+	res.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(lobby.ListRooms())
+}
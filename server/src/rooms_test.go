@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_Rooms(t *testing.T) {
+	t.Run("Can create and list rooms", should_create_and_list_rooms)
+	t.Run("Rejects duplicate room names", should_reject_duplicate_room_names)
+	t.Run("Reset only affects one room", should_reset_only_one_room)
+}
+
+func should_create_and_list_rooms(t *testing.T) {
+	//Arrange
+	lobby := Lobby{rooms: make(map[string]*BoardGame)}
+
+	//Act
+	_, err1 := lobby.CreateRoom("b-room")
+	_, err2 := lobby.CreateRoom("a-room")
+	rooms := lobby.ListRooms()
+
+	//Assert
+	if err1 != nil || err2 != nil {
+		t.Error("Unable to create rooms:", err1, err2)
+	}
+	if len(rooms) != 2 || rooms[0].Name != "a-room" || rooms[1].Name != "b-room" {
+		t.Error("Unexpected room list:", rooms)
+	}
+	if !rooms[0].NotStarted {
+		t.Error("New room should be in NotStarted state")
+	}
+}
+
+func should_reject_duplicate_room_names(t *testing.T) {
+	lobby := Lobby{rooms: make(map[string]*BoardGame)}
+
+	lobby.CreateRoom("room")
+	_, err := lobby.CreateRoom("room")
+	_, emptyErr := lobby.CreateRoom("  ")
+
+	if err == nil {
+		t.Error("Creating a room twice should fail")
+	}
+	if emptyErr == nil {
+		t.Error("Creating a room without a name should fail")
+	}
+}
+
+func should_reset_only_one_room(t *testing.T) {
+	//Arrange
+	lobby := Lobby{rooms: make(map[string]*BoardGame)}
+	first, _ := lobby.CreateRoom("first")
+	second, _ := lobby.CreateRoom("second")
+	first.State = WaitingForSuggestion
+	second.State = JorneyVoting
+	first.PlayerNames = append(first.PlayerNames, PlayerName{"alice"})
+
+	//Act
+	first.Reset()
+
+	//Assert
+	if first.State != NotStarted {
+		t.Error("Reset room should be in NotStarted state, got", first.State)
+	}
+	if len(first.PlayerNames) != 1 {
+		t.Error("Reset should keep seated players, got", first.PlayerNames)
+	}
+	if second.State != JorneyVoting {
+		t.Error("Other rooms should not be reset, got", second.State)
+	}
+	if first.manager.board != first {
+		t.Error("Manager should still point to the reset board")
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Seed(t *testing.T) {
+	t.Run("Replays seating, roles, lancelot cards and secrets from a seed", should_replay_game_from_seed)
+	t.Run("Records a drawn seed when none is given", should_record_drawn_seed)
+}
+
+var seededRoles = []string{Merlin, Percival, Gornemant, LoyalServentOfArthur, Assassin, Morgana, Mordred}
+
+func should_replay_game_from_seed(t *testing.T) {
+	//Arrange
+	seed := int64(42)
+
+	//Act
+	first := startTestGame(t, GameConfiguration{Lady: true, Seed: &seed}, seededRoles...)
+	second := startTestGame(t, GameConfiguration{Lady: true, Seed: &seed}, seededRoles...)
+
+	//Assert
+	if !reflect.DeepEqual(first.PlayerNames, second.PlayerNames) {
+		t.Error("Seating should be replayed, got", first.PlayerNames, second.PlayerNames)
+	}
+	if !reflect.DeepEqual(first.PlayerToCharacter, second.PlayerToCharacter) {
+		t.Error("Roles should be replayed, got", first.PlayerToCharacter, second.PlayerToCharacter)
+	}
+	if !reflect.DeepEqual(first.lancelotCards, second.lancelotCards) {
+		t.Error("Lancelot cards should be replayed, got", first.lancelotCards, second.lancelotCards)
+	}
+	if !reflect.DeepEqual(first.Secrets, second.Secrets) || !reflect.DeepEqual(first.SecretsMap, second.SecretsMap) {
+		t.Error("Secrets should be replayed, got", first.Secrets, second.Secrets)
+	}
+	if first.Seed != seed {
+		t.Error("Seed should be stored, got", first.Seed)
+	}
+}
+
+func should_record_drawn_seed(t *testing.T) {
+	//Arrange
+	board, config := seatTestGame(GameConfiguration{Lady: true}, seededRoles...)
+	if err := board.StartGameHandler(config); err != nil {
+		t.Fatal("Game should start, got", err)
+	}
+
+	//Act
+	replay := startTestGame(t, GameConfiguration{Lady: true, Seed: &board.Seed}, seededRoles...)
+
+	//Assert
+	if board.Seed == 0 || !reflect.DeepEqual(board.PlayerToCharacter, replay.PlayerToCharacter) {
+		t.Error("The recorded seed should replay the game, got", board.Seed)
+	}
+}
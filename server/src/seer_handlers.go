@@ -2,8 +2,7 @@ package main
 
 import (
 	"log"
-	"math/rand"
-	"time"
+	"sort"
 )
 
 type SirPick struct {
@@ -16,54 +15,50 @@ type SirMessageInternal struct {
 	Pick string `json:"pick"`
 }
 
-type SirMessage struct {
-	Tp      string             `json:"type"`
-	Content SirMessageInternal `json:"content"`
-}
-
 
 
-func HandleSir(m SirMessageInternal) {
-	globalMutex.Lock()
+func (board *BoardGame) HandleSir(m SirMessageInternal) error {
+	board.mutex.Lock()
 	pick := m.Pick
-	character := globalBoard.PlayerToCharacter[PlayerName{pick}]
-	SirPlayer := globalBoard.CharacterToPlayer[Seer]
-	globalBoard.Secrets[SirPlayer.Player] = append(globalBoard.Secrets[SirPlayer.Player], pick+" is "+character)
-	globalBoard.SecretsMap[SirPlayer.Player].PlayersWithUncoveredCharacters[pick] = character
+	character := board.PlayerToCharacter[PlayerName{pick}]
+	SirPlayer := board.CharacterToPlayer[Seer]
+	board.Secrets[SirPlayer.Player] = append(board.Secrets[SirPlayer.Player], pick+" is "+character)
+	board.SecretsMap[SirPlayer.Player].PlayersWithUncoveredCharacters[pick] = character
 
-	if BlanchefleurPlayer, ok := globalBoard.CharacterToPlayer[Blanchefleur]; ok  {
+	if BlanchefleurPlayer, ok := board.CharacterToPlayer[Blanchefleur]; ok  {
 		seerMap := make(map[string]bool)
 		seerMap[pick] = true
-		globalBoard.whoSeeWho[Seer] = seerMap
+		board.whoSeeWho[Seer] = seerMap
 
 		secrets := make([]string, 0)
 		keys := make([]string, 0)
 
-		for k := range globalBoard.whoSeeWho {
-			if globalBoard.whoSeeWho[k] != nil && len(globalBoard.whoSeeWho[k]) > 0 {
+		for k := range board.whoSeeWho {
+			if board.whoSeeWho[k] != nil && len(board.whoSeeWho[k]) > 0 {
 				keys = append(keys, k)
 			}
 
 		}
+		sort.Strings(keys)
 		log.Println(" WhoSeeWho keys with values    =     ", keys)
 
 
 		var See string
 
 		isFound:=false
-		random1 := rand.Intn(len(keys))
+		random1 := board.rng.Intn(len(keys))
 		var TruePlayer PlayerName
 		var TrueCharacter string
 		for !isFound {
 			log.Println(" random1    =     ", random1)
 			TrueCharacter = keys[random1]
-			TruePlayer = globalBoard.CharacterToPlayer[TrueCharacter]
+			TruePlayer = board.CharacterToPlayer[TrueCharacter]
 			log.Println(" TrueCharacter    =     ", TrueCharacter)
 			log.Println(" TruePlayer    =     ", TruePlayer)
-			random2 := rand.Intn(len(globalBoard.whoSeeWho[keys[random1]]))
+			random2 := board.rng.Intn(len(board.whoSeeWho[keys[random1]]))
 			log.Println(" random2    =     ", random2)
 			i :=0
-			for k := range globalBoard.whoSeeWho[keys[random1]] {
+			for _, k := range sortedKeys(board.whoSeeWho[keys[random1]]) {
 				if i == random2 {
 					if k != BlanchefleurPlayer.Player {
 						See = k
@@ -81,22 +76,22 @@ func HandleSir(m SirMessageInternal) {
 		secrets = append(secrets, TruePlayer.Player + " see " + See)
 		log.Println(TruePlayer.Player + " see " + See)
 
-		random3 := rand.Intn(len(globalBoard.Characters))
+		random3 := board.rng.Intn(len(board.Characters))
 		log.Println("random3 = ", random3)
-		for globalBoard.Characters[random3] == TrueCharacter || globalBoard.Characters[random3] == Blanchefleur {
-			random3 = (random3 + 1) % len(globalBoard.Characters)
+		for board.Characters[random3] == TrueCharacter || board.Characters[random3] == Blanchefleur {
+			random3 = (random3 + 1) % len(board.Characters)
 		}
-		log.Println("character for unseen = ", globalBoard.Characters[random3])
+		log.Println("character for unseen = ", board.Characters[random3])
 		unseenplayers := make([]string, 0)
-		for _, p := range globalBoard.PlayerNames {
-			if p == BlanchefleurPlayer || globalBoard.PlayerToCharacter[p] == globalBoard.Characters[random3] {
+		for _, p := range board.PlayerNames {
+			if p == BlanchefleurPlayer || board.PlayerToCharacter[p] == board.Characters[random3] {
 				continue
 			}
-			if globalBoard.whoSeeWho[globalBoard.Characters[random3]] == nil || len(globalBoard.whoSeeWho[globalBoard.Characters[random3]]) == 0 {
+			if board.whoSeeWho[board.Characters[random3]] == nil || len(board.whoSeeWho[board.Characters[random3]]) == 0 {
 				unseenplayers = append(unseenplayers, p.Player)
 				log.Println("found unseen = ", p.Player)
 			} else {
-				if _, ok := globalBoard.whoSeeWho[globalBoard.Characters[random3]][p.Player]; !ok  {
+				if _, ok := board.whoSeeWho[board.Characters[random3]][p.Player]; !ok  {
 					unseenplayers = append(unseenplayers, p.Player)
 					log.Println("found unseen = ", p.Player)
 				}
@@ -104,24 +99,24 @@ func HandleSir(m SirMessageInternal) {
 		}
 
 		log.Println("unseens all = ", unseenplayers)
-		FalseCharacter := globalBoard.Characters[random3]
-		FalsePlayer := globalBoard.CharacterToPlayer[FalseCharacter]
-		random4 := rand.Intn(len(unseenplayers))
+		FalseCharacter := board.Characters[random3]
+		FalsePlayer := board.CharacterToPlayer[FalseCharacter]
+		random4 := board.rng.Intn(len(unseenplayers))
 		log.Println("random4 = ", random4)
 		secrets = append(secrets, FalsePlayer.Player + " see " + unseenplayers[random4])
 
-		rand.Seed(int64(time.Now().Nanosecond()))
-		rand.Shuffle(len(secrets), func(i, j int) {
+		board.rng.Shuffle(len(secrets), func(i, j int) {
 			secrets[i], secrets[j] = secrets[j], secrets[i]
 		})
 		log.Println("secrets = ", secrets)
-		globalBoard.Secrets[BlanchefleurPlayer.Player] = secrets
+		board.Secrets[BlanchefleurPlayer.Player] = secrets
 	}
 
-	globalBoard.State = WaitingForSuggestion
-	suggesterIndex := globalBoard.suggestions.suggesterIndex
-	globalBoard.StateDescription = "Suggestion For Next Quest: " + globalBoard.PlayerNames[suggesterIndex].Player +
+	board.setState(WaitingForSuggestion)
+	suggesterIndex := board.suggestions.suggesterIndex
+	board.StateDescription = "Suggestion For Next Quest: " + board.PlayerNames[suggesterIndex].Player +
 		" is choosing players..."
 
-	globalMutex.Unlock()
+	board.mutex.Unlock()
+	return nil
 }
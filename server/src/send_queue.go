@@ -0,0 +1,149 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// sendQueueLimit bounds the messages waiting for a client's write thread.
+var sendQueueLimit = getEnvInt("WS_SEND_QUEUE", 64)
+
+type queuedMessage struct {
+	message  []byte
+	snapshot bool
+}
+
+/*
+sendQueue is a client's outbound queue. Pushing never blocks the manager thread.
+Slow consumer policy:
+  - a state snapshot replaces every snapshot still waiting in the queue (coalesced), since only
+    the latest state matters;
+  - when the queue is full, the oldest message that is not a snapshot is dropped;
+  - a client that cannot take even that is cut off by the write deadline of its socket.
+*/
+type sendQueue struct {
+	mutex     sync.Mutex
+	messages  []queuedMessage
+	ready     chan struct{}
+	closed    bool
+	limit     int
+	dropped   int64
+	coalesced int64
+}
+
+func newSendQueue(limit int) *sendQueue {
+	return &sendQueue{messages: make([]queuedMessage, 0), ready: make(chan struct{}, 1), limit: limit}
+}
+
+// push queues the message and returns how many messages were coalesced or dropped to make room.
+// ok is false if the queue was already closed.
+func (q *sendQueue) push(message []byte, snapshot bool) (coalesced int, dropped int, ok bool) {
+	q.mutex.Lock()
+	if q.closed {
+		q.mutex.Unlock()
+		return 0, 0, false
+	}
+	if snapshot {
+		kept := q.messages[:0]
+		for _, m := range q.messages {
+			if m.snapshot {
+				coalesced++
+			} else {
+				kept = append(kept, m)
+			}
+		}
+		q.messages = kept
+	}
+	if len(q.messages) >= q.limit {
+		index := SliceIndex(len(q.messages), func(i int) bool { return !q.messages[i].snapshot })
+		if index < 0 {
+			index = 0
+		}
+		q.messages = append(q.messages[:index], q.messages[index+1:]...)
+		dropped++
+	}
+	q.messages = append(q.messages, queuedMessage{message: message, snapshot: snapshot})
+	q.mutex.Unlock()
+
+	atomic.AddInt64(&q.coalesced, int64(coalesced))
+	atomic.AddInt64(&q.dropped, int64(dropped))
+	q.notify()
+	return coalesced, dropped, true
+}
+
+// pushReplay queues a resumed seat's replay as a whole. It is bounded by maxMissedMessages,
+// not by the queue limit, so nothing the player missed is dropped.
+func (q *sendQueue) pushReplay(messages [][]byte) bool {
+	q.mutex.Lock()
+	if q.closed {
+		q.mutex.Unlock()
+		return false
+	}
+	for _, message := range messages {
+		q.messages = append(q.messages, queuedMessage{message: message})
+	}
+	q.mutex.Unlock()
+	q.notify()
+	return true
+}
+
+// pop returns the next message. ok is false if the queue is empty.
+func (q *sendQueue) pop() ([]byte, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if len(q.messages) == 0 {
+		return nil, false
+	}
+	m := q.messages[0]
+	q.messages = q.messages[1:]
+	return m.message, true
+}
+
+// close lets the write thread flush what is queued and then close the socket.
+func (q *sendQueue) close() {
+	q.mutex.Lock()
+	q.closed = true
+	q.mutex.Unlock()
+	q.notify()
+}
+
+func (q *sendQueue) isClosed() bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.closed
+}
+
+func (q *sendQueue) notify() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+type sendStats struct {
+	Dropped   int64 `json:"dropped"`
+	Coalesced int64 `json:"coalesced"`
+}
+
+// enqueue pushes a message to a client of this room and counts what the slow consumer policy did.
+func (manager *ClientManager) enqueue(conn *Client, message []byte, snapshot bool) {
+	coalesced, dropped, ok := conn.send.push(message, snapshot)
+	if !ok {
+		return
+	}
+	atomic.AddInt64(&manager.stats.Coalesced, int64(coalesced))
+	atomic.AddInt64(&manager.stats.Dropped, int64(dropped))
+}
+
+func (manager *ClientManager) Stats() sendStats {
+	return sendStats{Dropped: atomic.LoadInt64(&manager.stats.Dropped), Coalesced: atomic.LoadInt64(&manager.stats.Coalesced)}
+}
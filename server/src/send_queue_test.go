@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_SendQueue(t *testing.T) {
+	t.Run("Coalesces queued state snapshots", should_coalesce_queued_snapshots)
+	t.Run("Drops the oldest message when full", should_drop_oldest_message_when_full)
+	t.Run("Does not accept messages after close", should_not_accept_messages_after_close)
+}
+
+func should_coalesce_queued_snapshots(t *testing.T) {
+	//Arrange
+	q := newSendQueue(10)
+
+	//Act
+	q.push([]byte("state1"), true)
+	q.push([]byte("chat"), false)
+	coalesced, _, _ := q.push([]byte("state2"), true)
+
+	//Assert
+	if coalesced != 1 {
+		t.Error("Expected one coalesced snapshot, got", coalesced)
+	}
+	first, _ := q.pop()
+	second, _ := q.pop()
+	_, more := q.pop()
+	if string(first) != "chat" || string(second) != "state2" || more {
+		t.Error("Unexpected queue content:", string(first), string(second), more)
+	}
+}
+
+func should_drop_oldest_message_when_full(t *testing.T) {
+	//Arrange
+	q := newSendQueue(2)
+
+	//Act
+	q.push([]byte("state"), true)
+	q.push([]byte("a"), false)
+	_, dropped, _ := q.push([]byte("b"), false)
+
+	//Assert
+	if dropped != 1 || q.dropped != 1 {
+		t.Error("Expected one dropped message, got", dropped)
+	}
+	first, _ := q.pop()
+	second, _ := q.pop()
+	if string(first) != "state" || string(second) != "b" {
+		t.Error("Snapshot should be kept and the oldest message dropped, got", string(first), string(second))
+	}
+}
+
+func should_not_accept_messages_after_close(t *testing.T) {
+	q := newSendQueue(2)
+
+	q.close()
+	_, _, ok := q.push([]byte("a"), false)
+
+	if ok || !q.isClosed() {
+		t.Error("Closed queue should not accept messages")
+	}
+}
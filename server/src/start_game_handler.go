@@ -3,16 +3,12 @@ package main
 import (
 	"log"
 	"math/rand"
+	"sort"
+	"strconv"
 	"time"
 )
 
 
-
-type StartGameMessage struct {
-	Tp      string            `json:"type"`
-	Content GameConfiguration `json:"content"`
-}
-
 type Ch struct {
 	Name     string `json:"name"`
 	Checked  bool   `json:"checked"`
@@ -20,19 +16,26 @@ type Ch struct {
 }
 
 type GameConfiguration struct {
-	Characters []Ch `json:"characters"`
-	Excalibur  bool `json:"excalibur"`
-	Lady       bool `json:"lady"`
+	Characters []Ch   `json:"characters"`
+	Excalibur  bool   `json:"excalibur"`
+	Lady       bool   `json:"lady"`
+	LadyOptions *LadyConfiguration `json:"ladyOptions,omitempty"`
+	Seed       *int64 `json:"seed,omitempty"` // replays a game. a new seed is drawn when empty
+	Board      *BoardConfiguration `json:"board,omitempty"` // the house layout for the number of players when empty
+	Lancelot   string              `json:"lancelot,omitempty"` // a lancelotVariants name, "house" when empty
+	HouseRules *HouseRules         `json:"houseRules,omitempty"`
+	Targeting  bool                `json:"targeting,omitempty"` // the assassin declares the role they go after
+	PlotCards  bool                `json:"plotCards,omitempty"`
 }
 
-func CreateOtherRolesDescriptions(character string) CharacterDescription {
-	assasinPlayer, _ := globalBoard.CharacterToPlayer[Assassin]
-	assassinCharacter := globalBoard.PlayerToCharacter[assasinPlayer]
+func (board *BoardGame) CreateOtherRolesDescriptions(character string) CharacterDescription {
+	assasinPlayer, _ := board.CharacterToPlayer[Assassin]
+	assassinCharacter := board.PlayerToCharacter[assasinPlayer]
 
 	desc := CharactersDescriptionMap[character]
 	newSlice := make([]string, 0)
 	for _, ch := range desc.CanSeeAsColor {
-		if _, ok := globalBoard.CharacterToPlayer[ch]; ok {
+		if _, ok := board.CharacterToPlayer[ch]; ok {
 			if ch != Assassin || assassinCharacter != Assassin {
 				newSlice = append(newSlice, ch)
 			}
@@ -42,7 +45,7 @@ func CreateOtherRolesDescriptions(character string) CharacterDescription {
 
 	newSlice = make([]string, 0)
 	for _, ch := range desc.CanSeeSpecifically {
-		if _, ok := globalBoard.CharacterToPlayer[ch]; ok {
+		if _, ok := board.CharacterToPlayer[ch]; ok {
 			if ch != Assassin || assassinCharacter != Assassin {
 				newSlice = append(newSlice, ch)
 			}
@@ -52,7 +55,7 @@ func CreateOtherRolesDescriptions(character string) CharacterDescription {
 
 	newSlice = make([]string, 0)
 	for _, ch := range desc.SeenAsColorBy {
-		if _, ok := globalBoard.CharacterToPlayer[ch]; ok {
+		if _, ok := board.CharacterToPlayer[ch]; ok {
 			if ch != Assassin || assassinCharacter != Assassin {
 				newSlice = append(newSlice, ch)
 			}
@@ -63,7 +66,7 @@ func CreateOtherRolesDescriptions(character string) CharacterDescription {
 
 	newSlice = make([]string, 0)
 	for _, ch := range desc.SeenSpecificallyBy {
-		if _, ok := globalBoard.CharacterToPlayer[ch]; ok {
+		if _, ok := board.CharacterToPlayer[ch]; ok {
 			if ch != Assassin || assassinCharacter != Assassin {
 				newSlice = append(newSlice, ch)
 			}
@@ -73,7 +76,7 @@ func CreateOtherRolesDescriptions(character string) CharacterDescription {
 
 	newSlice = make([]string, 0)
 	for _, ch := range desc.Murder {
-		if _, ok := globalBoard.CharacterToPlayer[ch]; ok {
+		if _, ok := board.CharacterToPlayer[ch]; ok {
 			newSlice = append(newSlice, ch)
 		}
 	}
@@ -81,7 +84,7 @@ func CreateOtherRolesDescriptions(character string) CharacterDescription {
 
 	newSlice = make([]string, 0)
 	for _, ch := range desc.Murder {
-		if _, ok := globalBoard.CharacterToPlayer[ch]; ok {
+		if _, ok := board.CharacterToPlayer[ch]; ok {
 			newSlice = append(newSlice, ch)
 		}
 	}
@@ -89,9 +92,9 @@ func CreateOtherRolesDescriptions(character string) CharacterDescription {
 
 	newSlice = make([]string, 0)
 	for _, ch := range desc.MurderedBy {
-		if _, ok := globalBoard.CharacterToPlayer[ch]; ok {
-			_, KingClaudinExists := isCharacterExists(true, KingClaudin)
-			_, PrinceClaudinExists := isCharacterExists(true, PrinceClaudin)
+		if _, ok := board.CharacterToPlayer[ch]; ok {
+			_, KingClaudinExists := board.isCharacterExists(true, KingClaudin)
+			_, PrinceClaudinExists := board.isCharacterExists(true, PrinceClaudin)
 			if ch == Percival {
 				if KingClaudinExists && PrinceClaudinExists {
 					newSlice = append(newSlice, ch)
@@ -113,36 +116,26 @@ func CreateOtherRolesDescriptions(character string) CharacterDescription {
 }
 
 
-func StartGameHandler(newGameConfig GameConfiguration) {
+// describeRoles builds the descriptions of the roles in the game, and of the role the Stray took.
+func (board *BoardGame) describeRoles() {
+	board.OtherRolesDescriptions = make(map[string]CharacterDescription)
+	for _, ch := range board.Characters {
+		board.OtherRolesDescriptions[ch] = board.CreateOtherRolesDescriptions(ch)
+	}
+	str, ok := board.CharacterToPlayer[Stray]
+	if ok {
+		strayNewCharacter := board.PlayerToCharacter[str]
+		board.OtherRolesDescriptions[strayNewCharacter] = board.CreateOtherRolesDescriptions(strayNewCharacter)
+	}
+}
+
+func (board *BoardGame) StartGameHandler(newGameConfig GameConfiguration) error {
 	log.Println("newGameConfig", newGameConfig)
-	globalMutex.Lock()
+	board.mutex.Lock()
 
 	chosenCharacters := make([]string, 0)
-	numOfPlayers := len(globalBoard.PlayerNames)
-	requiredBads := globalConfigPerNumOfPlayers[numOfPlayers].NumOfBadCharacters
-
-	rand.Seed(int64(time.Now().Nanosecond()))
-	rand.Shuffle(len(globalBoard.PlayerNames), func(i, j int) {
-		globalBoard.PlayerNames[i], globalBoard.PlayerNames[j] = globalBoard.PlayerNames[j], globalBoard.PlayerNames[i]
-	})
-
-	if newGameConfig.Excalibur == true {
-		globalBoard.quests.Flags[EXCALIBUR] = true
-		log.Println("excalibur - on ")
-	}
+	numOfPlayers := len(board.PlayerNames)
 
-	if newGameConfig.Lady == true {
-		globalBoard.quests.Flags[LADY] = true
-		globalBoard.ladyOfTheLake.currentSuggester = globalBoard.PlayerNames[len(globalBoard.PlayerNames)-1].Player
-		log.Println("lady - on ")
-	}
-
-	globalBoard.lancelotCards = []int{0, 0, 1, 0, 1, 0, 0}
-	rand.Seed(int64(time.Now().Nanosecond()))
-	rand.Shuffle(len(globalBoard.lancelotCards), func(i, j int) {
-		globalBoard.lancelotCards[i], globalBoard.lancelotCards[j] = globalBoard.lancelotCards[j], globalBoard.lancelotCards[i]
-	})
-	log.Println("===========", globalBoard.lancelotCards)
 	var numOfBads int
 	var numOfGood int
 	var hasEctor bool
@@ -151,93 +144,145 @@ func StartGameHandler(newGameConfig GameConfiguration) {
 			if v.Name == Ector {
 				hasEctor = true //need to use smaller board game in this case
 			}
-			if badCharacters[v.Name] == true {
-				numOfBads++
-			} else if goodCharacters[v.Name] == true {
-				numOfGood++
-			} else if v.Name == "Puck" {
-				numOfGood++
-			} else if v.Name == "Ginerva" || v.Name == "Gawain" || v.Name == TheQuestingBeast {
+			rules := characterRules(v.Name)
+			if rules == nil {
+				board.mutex.Unlock()
+				return newCommandError(ErrInvalidConfig, "unknown character "+v.Name)
+			}
+			if rules.Side == BAD {
 				numOfBads++
 			} else {
-				globalMutex.Unlock()
-				return
+				numOfGood++
 			}
 
 		}
 	}
 
+	activePlayers := numOfPlayers
+	if hasEctor {
+		activePlayers--
+	}
+	layout, err := chooseLayout(newGameConfig.Board, numOfPlayers, activePlayers)
+	if err != nil {
+		board.mutex.Unlock()
+		return err
+	}
+	requiredBads := layout.NumOfBadCharacters
+	lancelot, err := chooseLancelotVariant(newGameConfig.Lancelot)
+	if err != nil {
+		board.mutex.Unlock()
+		return err
+	}
+	rules, err := chooseRules(newGameConfig.HouseRules, &layout)
+	if err != nil {
+		board.mutex.Unlock()
+		return err
+	}
+	if err := board.validateLadyOptions(newGameConfig.LadyOptions, layout.NumOfQuests); err != nil {
+		board.mutex.Unlock()
+		return err
+	}
+
 	//sanity
 	if requiredBads != numOfBads {
-		globalMutex.Unlock()
-		return
+		board.mutex.Unlock()
+		return newCommandError(ErrInvalidConfig, strconv.Itoa(numOfPlayers)+" players need "+strconv.Itoa(requiredBads)+
+			" bad characters, got "+strconv.Itoa(numOfBads))
 	}
 
 	//sanity
 	if numOfPlayers != (numOfGood + numOfBads) {
-		globalMutex.Unlock()
-		return
+		board.mutex.Unlock()
+		return newCommandError(ErrInvalidConfig, strconv.Itoa(numOfPlayers)+" players need "+strconv.Itoa(numOfPlayers)+
+			" characters, got "+strconv.Itoa(numOfGood+numOfBads))
+	}
+
+	board.layout = layout
+	board.rules = rules
+	board.targeting = newGameConfig.Targeting
+	board.Seed = time.Now().UnixNano()
+	if newGameConfig.Seed != nil {
+		board.Seed = *newGameConfig.Seed
+	}
+	board.rng = rand.New(rand.NewSource(board.Seed))
+	log.Println("game seed:", board.Seed)
+
+	board.rng.Shuffle(len(board.PlayerNames), func(i, j int) {
+		board.PlayerNames[i], board.PlayerNames[j] = board.PlayerNames[j], board.PlayerNames[i]
+	})
+
+	if newGameConfig.Excalibur == true {
+		board.quests.Flags[EXCALIBUR] = true
+		log.Println("excalibur - on ")
 	}
 
-	chosenCharacters, assassinPlayer := assignCharactersToRegisteredPlayers(newGameConfig.Characters, chosenCharacters)
+	if newGameConfig.Lady == true {
+		board.quests.Flags[LADY] = true
+		board.startLady(newGameConfig.LadyOptions)
+		log.Println("lady - on ")
+	}
+
+	board.lancelot = lancelot
+	board.shuffleLancelotCards()
+
+	chosenCharacters, assassinPlayer := board.assignCharactersToRegisteredPlayers(newGameConfig.Characters, chosenCharacters)
 	if chosenCharacters == nil {
 		log.Fatal("No assassin chosen")
 	}
 
-	rand.Seed(int64(time.Now().Nanosecond()))
-	rand.Shuffle(len(chosenCharacters), func(i, j int) {
+	board.rng.Shuffle(len(chosenCharacters), func(i, j int) {
 		chosenCharacters[i], chosenCharacters[j] = chosenCharacters[j], chosenCharacters[i]
 	})
 	log.Println("chosen characters: ", chosenCharacters)
-	if _, ok := globalBoard.CharacterToPlayer[Seer]; ok {
-		globalBoard.State = SirPickPlayer
-		globalBoard.StateDescription = "Seer is choosing player to see..."
+	if _, ok := board.CharacterToPlayer[Seer]; ok {
+		board.setState(SirPickPlayer)
+		board.StateDescription = "Seer is choosing player to see..."
 	} else {
-		globalBoard.State = WaitingForSuggestion
-		suggesterIndex := globalBoard.suggestions.suggesterIndex
-		globalBoard.StateDescription = "Suggestion For Next Quest: " + globalBoard.PlayerNames[suggesterIndex].Player +
+		board.setState(WaitingForSuggestion)
+		suggesterIndex := board.suggestions.suggesterIndex
+		board.StateDescription = "Suggestion For Next Quest: " + board.PlayerNames[suggesterIndex].Player +
 			" is choosing players..."
 	}
-	if globalBoard.quests.results == nil {
-		globalBoard.quests.results = make(map[int]QuestStats)
+	if board.quests.results == nil {
+		board.quests.results = make(map[int]QuestStats)
 	}
 
 	//Ector
 	if hasEctor {
-		globalBoard.numOfPlayers = len(globalBoard.PlayerNames) - 1
+		board.numOfPlayers = len(board.PlayerNames) - 1
 	} else {
-		globalBoard.numOfPlayers = len(globalBoard.PlayerNames)
+		board.numOfPlayers = len(board.PlayerNames)
 	}
-	globalBoard.numOfConnectedPlayers = len(globalBoard.PlayerNames)
-	globalBoard.Characters = chosenCharacters
+	board.numOfConnectedPlayers = len(board.PlayerNames)
+	board.Characters = chosenCharacters
 
-	_, hasMeliagant := isCharacterExists(true, Meliagant)
+	_, hasMeliagant := board.isCharacterExists(true, Meliagant)
 
-	for i := 0; i < globalConfigPerNumOfPlayers[globalBoard.numOfPlayers].NumOfQuests; i++ {
+	for i := 0; i < board.layout.NumOfQuests; i++ {
 		en := QuestStats{}
-		en.Ppp = getTypeOfLevel(i+1, len(globalBoard.PlayerNames))
-		en.NumOfPlayers = globalConfigPerNumOfPlayers[globalBoard.numOfPlayers].PlayersPerLevel[i]
+		en.Ppp = board.layout.typeOfLevel(i + 1)
+		en.NumOfPlayers = board.layout.PlayersPerLevel[i]
 		if hasMeliagant {
 			en.NumOfPlayers--
 		}
-		globalBoard.quests.results[i+1] = en
+		board.quests.results[i+1] = en
 		log.Println(en)
 	}
-	globalBoard.suggestions.suggesterIndex = 0
+	board.suggestions.suggesterIndex = 0
 
-	numOfUnsuccesfulRetries := globalConfigPerNumOfPlayers[globalBoard.numOfPlayers].RetriesPerLevel[globalBoard.quests.current]
-	suggesterVetoIn := (globalBoard.suggestions.suggesterIndex + numOfUnsuccesfulRetries - 1) % len(globalBoard.PlayerNames)
-	globalBoard.suggestions.PlayerWithVeto = globalBoard.PlayerNames[suggesterVetoIn].Player
+	board.setVetoPlayer(board.suggestions.suggesterIndex, board.quests.current)
 
 	WhoSeeWho := make(map[string]map[string]bool)
-	for _, player := range globalBoard.PlayerNames {
-		globalBoard.SecretsMap[player.Player], globalBoard.Secrets[player.Player], globalBoard.whoSeeWho = GetSecretsFromPlayerName(player, WhoSeeWho)
-		log.Println(player, " Secrets     =     ", globalBoard.Secrets[player.Player])
+	for _, player := range board.PlayerNames {
+		board.SecretsMap[player.Player], board.Secrets[player.Player], board.whoSeeWho = board.GetSecretsFromPlayerName(player, WhoSeeWho)
+		log.Println(player, " Secrets     =     ", board.Secrets[player.Player])
 		log.Println(player, " WhoSeeWho     =     ", WhoSeeWho)
 	}
+	board.startPlotCards(newGameConfig.PlotCards)
+	board.startMessengers()
 
-	_, hasSeer := globalBoard.CharacterToPlayer[Seer]
-	if BlanchefleurPlayer, ok := globalBoard.CharacterToPlayer[Blanchefleur]; ok && !hasSeer {
+	_, hasSeer := board.CharacterToPlayer[Seer]
+	if BlanchefleurPlayer, ok := board.CharacterToPlayer[Blanchefleur]; ok && !hasSeer {
 		secrets := make([]string, 0)
 		secrets_tmp := make([]string, 0)
 		tmp := make(map[string]string)
@@ -250,25 +295,26 @@ func StartGameHandler(newGameConfig GameConfiguration) {
 			}
 
 		}
+		sort.Strings(keys)
 		log.Println(" WhoSeeWho keys with values    =     ", keys)
 
 
 		var See string
 
 		isFound:=false
-		random1 := rand.Intn(len(keys))
+		random1 := board.rng.Intn(len(keys))
 		var TruePlayer PlayerName
 		var TrueCharacter string
 		for !isFound {
 			log.Println(" random1    =     ", random1)
 			TrueCharacter = keys[random1]
-			TruePlayer = globalBoard.CharacterToPlayer[TrueCharacter]
+			TruePlayer = board.CharacterToPlayer[TrueCharacter]
 			log.Println(" TrueCharacter    =     ", TrueCharacter)
 			log.Println(" TruePlayer    =     ", TruePlayer)
-			random2 := rand.Intn(len(WhoSeeWho[keys[random1]]))
+			random2 := board.rng.Intn(len(WhoSeeWho[keys[random1]]))
 			log.Println(" random2    =     ", random2)
 			i :=0
-			for k := range WhoSeeWho[keys[random1]] {
+			for _, k := range sortedKeys(WhoSeeWho[keys[random1]]) {
 				if i == random2 {
 					if k != BlanchefleurPlayer.Player {
 						See = k
@@ -286,26 +332,26 @@ func StartGameHandler(newGameConfig GameConfiguration) {
 		secrets = append(secrets, TruePlayer.Player + " see " + See)
 		secrets_tmp = append(secrets_tmp, TruePlayer.Player)
 		tmp[TruePlayer.Player] = See
-		//globalBoard.SecretsMap[BlanchefleurPlayer.Player].PlayerSeePlayer[TruePlayer.Player] = See
+		//board.SecretsMap[BlanchefleurPlayer.Player].PlayerSeePlayer[TruePlayer.Player] = See
 
 		log.Println(TruePlayer.Player + " see " + See)
 
-		random3 := rand.Intn(len(globalBoard.Characters))
+		random3 := board.rng.Intn(len(board.Characters))
 		log.Println("random3 = ", random3)
-		for globalBoard.Characters[random3] == TrueCharacter || globalBoard.Characters[random3] == Blanchefleur {
-			random3 = (random3 + 1) % len(globalBoard.Characters)
+		for board.Characters[random3] == TrueCharacter || board.Characters[random3] == Blanchefleur {
+			random3 = (random3 + 1) % len(board.Characters)
 		}
-		log.Println("character for unseen = ", globalBoard.Characters[random3])
+		log.Println("character for unseen = ", board.Characters[random3])
 		unseenplayers := make([]string, 0)
-		for _, p := range globalBoard.PlayerNames {
-			if p == BlanchefleurPlayer || globalBoard.PlayerToCharacter[p] == globalBoard.Characters[random3] {
+		for _, p := range board.PlayerNames {
+			if p == BlanchefleurPlayer || board.PlayerToCharacter[p] == board.Characters[random3] {
 				continue
 			}
-			if WhoSeeWho[globalBoard.Characters[random3]] == nil || len(WhoSeeWho[globalBoard.Characters[random3]]) == 0 {
+			if WhoSeeWho[board.Characters[random3]] == nil || len(WhoSeeWho[board.Characters[random3]]) == 0 {
 				unseenplayers = append(unseenplayers, p.Player)
 				log.Println("found unseen = ", p.Player)
 			} else {
-				if _, ok := WhoSeeWho[globalBoard.Characters[random3]][p.Player]; !ok  {
+				if _, ok := WhoSeeWho[board.Characters[random3]][p.Player]; !ok  {
 					unseenplayers = append(unseenplayers, p.Player)
 					log.Println("found unseen = ", p.Player)
 				}
@@ -313,9 +359,9 @@ func StartGameHandler(newGameConfig GameConfiguration) {
 		}
 
 		log.Println("unseens all = ", unseenplayers)
-		FalseCharacter := globalBoard.Characters[random3]
-		FalsePlayer := globalBoard.CharacterToPlayer[FalseCharacter]
-		random4 := rand.Intn(len(unseenplayers))
+		FalseCharacter := board.Characters[random3]
+		FalsePlayer := board.CharacterToPlayer[FalseCharacter]
+		random4 := board.rng.Intn(len(unseenplayers))
 		log.Println("random4 = ", random4)
 		secrets = append(secrets, FalsePlayer.Player + " see " + unseenplayers[random4])
 
@@ -323,60 +369,51 @@ func StartGameHandler(newGameConfig GameConfiguration) {
 		tmp[FalsePlayer.Player] = unseenplayers[random4]
 
 
-		rand.Seed(int64(time.Now().Nanosecond()))
-		rand.Shuffle(len(secrets), func(i, j int) {
+		board.rng.Shuffle(len(secrets), func(i, j int) {
 			secrets[i], secrets[j] = secrets[j], secrets[i]
 		})
 
-		rand.Seed(int64(time.Now().Nanosecond()))
-		rand.Shuffle(len(secrets_tmp), func(i, j int) {
+		board.rng.Shuffle(len(secrets_tmp), func(i, j int) {
 			secrets_tmp[i], secrets_tmp[j] = secrets_tmp[j], secrets_tmp[i]
 		})
 
 		log.Println("secrets = ", secrets)
-		globalBoard.SecretsMap[BlanchefleurPlayer.Player].PlayerSee =  secrets_tmp[0]
-		globalBoard.SecretsMap[BlanchefleurPlayer.Player].Seen = tmp[secrets_tmp[0]]
+		board.SecretsMap[BlanchefleurPlayer.Player].PlayerSee =  secrets_tmp[0]
+		board.SecretsMap[BlanchefleurPlayer.Player].Seen = tmp[secrets_tmp[0]]
 
-		globalBoard.SecretsMap[BlanchefleurPlayer.Player].PlayerSee2 =  secrets_tmp[1]
-		globalBoard.SecretsMap[BlanchefleurPlayer.Player].Seen2 = tmp[secrets_tmp[1]]
+		board.SecretsMap[BlanchefleurPlayer.Player].PlayerSee2 =  secrets_tmp[1]
+		board.SecretsMap[BlanchefleurPlayer.Player].Seen2 = tmp[secrets_tmp[1]]
 
-		globalBoard.Secrets[BlanchefleurPlayer.Player] = secrets
+		board.Secrets[BlanchefleurPlayer.Player] = secrets
 	}
 
-	_, hasBadLancelot := globalBoard.CharacterToPlayer[LancelotBad]
-	_, hasGoodLancelot := globalBoard.CharacterToPlayer[LancelotGood]
+	_, hasBadLancelot := board.CharacterToPlayer[LancelotBad]
+	_, hasGoodLancelot := board.CharacterToPlayer[LancelotGood]
 	if hasBadLancelot && hasGoodLancelot {
-		globalBoard.quests.Flags[HAS_TWO_LANCELOT] = true
+		board.quests.Flags[HAS_TWO_LANCELOT] = true
 	} else if hasBadLancelot {
-		globalBoard.quests.Flags[HAS_ONLY_BAD_LANCELOT] = true
+		board.quests.Flags[HAS_ONLY_BAD_LANCELOT] = true
 	} else if hasGoodLancelot {
-		globalBoard.quests.Flags[HAS_ONLY_GOOD_LANCELOT] = true
+		board.quests.Flags[HAS_ONLY_GOOD_LANCELOT] = true
 	}
 
-	_, hasBalain := globalBoard.CharacterToPlayer[Balain]
-	_, hasBalin := globalBoard.CharacterToPlayer[Balin]
+	_, hasBalain := board.CharacterToPlayer[Balain]
+	_, hasBalin := board.CharacterToPlayer[Balin]
 	if hasBalain && hasBalin {
-		globalBoard.quests.Flags[HAS_BALAIN_AND_BALIN] = true
+		board.quests.Flags[HAS_BALAIN_AND_BALIN] = true
 	}
 
-	globalBoard.CharacterToPlayer[Assassin] = PlayerName{assassinPlayer}
+	board.CharacterToPlayer[Assassin] = PlayerName{assassinPlayer}
 
 
-	globalBoard.OtherRolesDescriptions = make(map[string]CharacterDescription)
-	for _, ch := range globalBoard.Characters {
-		globalBoard.OtherRolesDescriptions[ch] = CreateOtherRolesDescriptions(ch)
-	}
-	str, ok := globalBoard.CharacterToPlayer[Stray]
-	if ok {
-		strayNewCharacter := globalBoard.PlayerToCharacter[str]
-		globalBoard.OtherRolesDescriptions[strayNewCharacter] = CreateOtherRolesDescriptions(strayNewCharacter)
-	}
+	board.describeRoles()
 
-	globalMutex.Unlock()
+	board.mutex.Unlock()
+	return nil
 }
 
 
-func GetSecretsFromPlayerName(player PlayerName, whoSeeWho map[string]map[string]bool) (*PlayerSecrets, []string, map[string]map[string]bool) {
+func (board *BoardGame) GetSecretsFromPlayerName(player PlayerName, whoSeeWho map[string]map[string]bool) (*PlayerSecrets, []string, map[string]map[string]bool) {
 
 	secrets := make([]string, 0)
 	if player.Player == "" {
@@ -388,520 +425,331 @@ func GetSecretsFromPlayerName(player PlayerName, whoSeeWho map[string]map[string
 		PlayersWithBadCharacter: make([]string, 0),
 		PlayersWithUncoveredCharacters: make(map[string]string)}
 
-	strayPlayer, _ := globalBoard.CharacterToPlayer[Stray]
-	character := globalBoard.PlayerToCharacter[player]
+	strayPlayer, _ := board.CharacterToPlayer[Stray]
+	character := board.PlayerToCharacter[player]
 
-	if character == Gornemant {
-		bads := make([]string, 0)
-		goods := make([]string, 0)
-		for _, c := range globalBoard.Characters {
-			if c == Stray {
-				c = globalBoard.PlayerToCharacter[strayPlayer]
-			}
-			if _, ok := goodCharacters[c]; ok {
-				goods = append(goods, c)
-			} else {
-				bads = append(bads, c)
-			}
+	n := &night{board: board, player: player, character: character, strayPlayer: strayPlayer, secrets: secrets,
+		secret: &playerSecret, whoSeeWho: whoSeeWho}
+	if rules := characterRules(character); rules != nil {
+		if rules.Night != nil {
+			rules.Night(n)
 		}
-		sameTeamTakenFromBads := rand.Intn(2)
-		var sameTeam []string
-		var notSameTeam []string
-		if sameTeamTakenFromBads == 1 {
-			sameTeam = bads
-			notSameTeam = goods
-			log.Println("sameTeamTakenFromBads == 1")
-		} else {
-			sameTeam = goods
-			notSameTeam = bads
+		listedRolesNight(n, rules)
+		if rules.Loyalty == GOOD && !rules.UnawareOfNirlem {
+			goodsNight(n)
 		}
-		log.Println("sameTeam =", sameTeam, "len=", len(sameTeam))
-		log.Println("notSameTeam =", notSameTeam, "len=", len(notSameTeam))
-
-		sameTeam = removeElementFromSlice(player, sameTeam)
-
-		notSameTeam = removeElementFromSlice(player, notSameTeam)
-
-
-		random1 := rand.Intn(len(sameTeam))
-		random2 := rand.Intn(len(sameTeam))
-		Player1 := globalBoard.CharacterToPlayer[sameTeam[random1]].Player
-		Player2 := globalBoard.CharacterToPlayer[sameTeam[random2]].Player
-		if random2 == random1 {
-			random2 = (random2 + 1) % len(sameTeam)
-			Player2 = globalBoard.CharacterToPlayer[sameTeam[random2]].Player
+		if rules.Loyalty == BAD && !rules.UnawareOfEvil {
+			evilNight(n)
 		}
+	}
+	if board.lancelot.KnowEachOther {
+		lancelotsNight(n)
+	}
+	secrets = n.secrets
 
-		log.Println("random1 =", random1, " random2 = ", random2)
+	// the lists above are filled while ranging over maps. sort them so the shuffle only depends on the seed
+	sort.Strings(secrets)
+	board.rng.Shuffle(len(secrets), func(i, j int) {
+		secrets[i], secrets[j] = secrets[j], secrets[i]
+	})
 
-		secrets = append(secrets, Player1+" and "+Player2)
-		playerSecret.PlayersWithSameLoyalty = []string{Player1, Player2}
+	sort.Strings(playerSecret.PlayersWithBadCharacter)
+	board.rng.Shuffle(len(playerSecret.PlayersWithBadCharacter), func(i, j int) {
+		playerSecret.PlayersWithBadCharacter[i], playerSecret.PlayersWithBadCharacter[j] = playerSecret.PlayersWithBadCharacter[j], playerSecret.PlayersWithBadCharacter[i]
+	})
 
-		sameTeam = removeElementFromStringSlice(sameTeam, random1)
+	sort.Strings(playerSecret.PlayersWithGoodCharacter)
+	board.rng.Shuffle(len(playerSecret.PlayersWithGoodCharacter), func(i, j int) {
+		playerSecret.PlayersWithGoodCharacter[i], playerSecret.PlayersWithGoodCharacter[j] = playerSecret.PlayersWithGoodCharacter[j], playerSecret.PlayersWithGoodCharacter[i]
+	})
 
-		idx := 0
-		isFound := false
-		for i, _ := range sameTeam {
-			if Player2 != globalBoard.CharacterToPlayer[sameTeam[i]].Player {
-				idx++
-			} else {
-				isFound = true
-				break
-			}
-		}
-		if isFound {
-			sameTeam = removeElementFromStringSlice(sameTeam, idx)
-		}
+	log.Println("character:", character)
+	log.Println("secrets:", secrets)
+	log.Println("new secrets:", playerSecret)
+	return &playerSecret, secrets, whoSeeWho
+}
 
-		log.Println("new sameTeam =", sameTeam)
-		random3 := rand.Intn(len(notSameTeam))
-		random4 := rand.Intn(len(sameTeam))
-		log.Println("random3 =", random3, " random4 = ", random4)
-		Player3 := globalBoard.CharacterToPlayer[notSameTeam[random3]].Player
-		Player4 := globalBoard.CharacterToPlayer[sameTeam[random4]].Player
+// night collects what one player learns when the game starts.
+type night struct {
+	board       *BoardGame
+	player      PlayerName
+	character   string
+	strayPlayer PlayerName
+	secrets     []string
+	secret      *PlayerSecrets
+	whoSeeWho   map[string]map[string]bool
+}
 
-		secrets = append(secrets, Player3+" and "+Player4)
-		playerSecret.PlayersWithDifferentLoyalty = []string{Player3, Player4}
+func (n *night) see(player string) {
+	mapp := n.whoSeeWho[n.character]
+	if mapp == nil {
+		mapp = make(map[string]bool)
+		n.whoSeeWho[n.character] = mapp
 	}
+	mapp[player] = true
+}
 
-	if character == Meliagant {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
+func (n *night) sees(player string) bool {
+	return n.whoSeeWho[n.character][player]
+}
 
-		for k, v := range globalBoard.CharacterToPlayer {
+// reveal shows player as the given role, with text as the secret line.
+func (n *night) reveal(player string, text string, as string) {
+	n.secrets = append(n.secrets, text)
+	n.secret.PlayersWithUncoveredCharacters[player] = as
+	n.see(player)
+}
 
-			if _, ok := badCharacters[k]; ok {
-				if v.Player == strayPlayer.Player {
-					secrets = append(secrets, v.Player+" is Stray")
-					playerSecret.PlayersWithUncoveredCharacters[v.Player] = Stray
+func (n *night) uncover(player string, character string) {
+	n.reveal(player, player+" is "+character, character)
+}
 
-				} else {
-					secrets = append(secrets, v.Player+" is "+k)
-					playerSecret.PlayersWithUncoveredCharacters[v.Player] = k
-				}
+func (n *night) uncoverBad(player string) {
+	n.secrets = append(n.secrets, player+" is bad")
+	n.secret.PlayersWithBadCharacter = append(n.secret.PlayersWithBadCharacter, player)
+	n.see(player)
+}
 
-				mapp[v.Player] = true
-			} else {
-				if k == Lot {
-					secrets = append(secrets, v.Player+" is Lot")
-					playerSecret.PlayersWithUncoveredCharacters[v.Player] = Lot
-					mapp[v.Player] = true
-				}
-			}
+func (n *night) uncoverGood(player string) {
+	n.secrets = append(n.secrets, player+" is good")
+	n.secret.PlayersWithGoodCharacter = append(n.secret.PlayersWithGoodCharacter, player)
+	n.see(player)
+}
+
+// listedRolesNight shows the roles in SeesSpecifically and SeesAsColor. Neutral roles have no colour.
+func listedRolesNight(n *night, rules *CharacterRules) {
+	for _, c := range rules.SeesSpecifically {
+		if v, ok := n.board.CharacterToPlayer[c]; ok {
+			n.uncover(v.Player, c)
 		}
-		whoSeeWho[Meliagant] = mapp
 	}
-
-	if character == Merlin {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
+	for _, c := range rules.SeesAsColor {
+		v, ok := n.board.CharacterToPlayer[c]
+		if !ok {
+			continue
 		}
-
-		for k, v := range globalBoard.CharacterToPlayer {
-
-			if _, ok := badCharacters[k]; ok && k != Mordred && k != Accolon {
-				if k == Oberon {
-					secrets = append(secrets, v.Player+" is Oberon")
-					playerSecret.PlayersWithUncoveredCharacters[v.Player] = Oberon
-					mapp[v.Player] = true
-				} else {
-					secrets = append(secrets, v.Player+" is bad")
-					playerSecret.PlayersWithBadCharacter = append(playerSecret.PlayersWithBadCharacter, v.Player)
-					mapp[v.Player] = true
-				}
-			}
-			if k == Stray {
-				secrets = append(secrets, v.Player+" is Stray")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Stray
-				mapp[v.Player] = true
-			}
-			if k == Lot {
-				secrets = append(secrets, v.Player+" is Lot")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Lot
-				mapp[v.Player] = true
-			}
-			if k == Meliagant {
-				secrets = append(secrets, v.Player+" is bad")
-				playerSecret.PlayersWithBadCharacter = append(playerSecret.PlayersWithBadCharacter, v.Player)
-				mapp[v.Player] = true
-			}
-			if k == "Ginerva" {
-				secrets = append(secrets, v.Player+" is bad")
-				playerSecret.PlayersWithBadCharacter = append(playerSecret.PlayersWithBadCharacter, v.Player)
-				mapp[v.Player] = true
-			}
-			if k == SirKay {
-				secrets = append(secrets, v.Player+" is bad")
-				playerSecret.PlayersWithBadCharacter = append(playerSecret.PlayersWithBadCharacter, v.Player)
-				mapp[v.Player] = true
-			}
-			if k == "Gawain" {
-				secrets = append(secrets, v.Player+" is Gawain")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = "Gawain"
-				mapp[v.Player] = true
-			}
+		switch characterRules(c).Side {
+		case BAD:
+			n.uncoverBad(v.Player)
+		case GOOD:
+			n.uncoverGood(v.Player)
 		}
-		whoSeeWho[Merlin] = mapp
 	}
-	if _, ok := goodCharacters[character]; ok && character != Nirlem && character != Lot && character != Meliagant {
-		if nirlem, ok := globalBoard.CharacterToPlayer[Nirlem]; ok && character != LancelotGood && character != Balain {
-			mapp := whoSeeWho[character]
-			if mapp == nil {
-				mapp = make(map[string]bool)
-			}
-			secrets = append(secrets, nirlem.Player+" is Nirlem")
-			playerSecret.PlayersWithUncoveredCharacters[nirlem.Player] = Nirlem
-			mapp[nirlem.Player] = true
-			whoSeeWho[character] = mapp
-		}
-	}
-	if character == Guinevere {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == LancelotGood {
-				secrets = append(secrets, v.Player+" is Lancelot")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = "Lancelot"
-				mapp[v.Player] = true
-			}
-			if k == LancelotBad {
-				secrets = append(secrets, v.Player+" is Lancelot")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = "Lancelot"
-				mapp[v.Player] = true
+}
+
+// seesRoles uncovers the players of the given roles.
+func seesRoles(characters ...string) func(n *night) {
+	return func(n *night) {
+		for _, c := range characters {
+			if v, ok := n.board.CharacterToPlayer[c]; ok {
+				n.uncover(v.Player, c)
 			}
 		}
-		whoSeeWho[character] = mapp
 	}
-	if character == Iseult {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == Tristan {
-				secrets = append(secrets, v.Player+" is Tristan")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Tristan
-				mapp[v.Player] = true
-			}
-		}
-		whoSeeWho[character] = mapp
+}
+
+// Goods see Nirlem.
+func goodsNight(n *night) {
+	if nirlem, ok := n.board.CharacterToPlayer[Nirlem]; ok {
+		n.uncover(nirlem.Player, Nirlem)
 	}
-	if character == Balin {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == Balain {
-				secrets = append(secrets, v.Player+" is Balain")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Balain
-				mapp[v.Player] = true
+}
+
+// The bads see each other, and the Stray.
+func evilNight(n *night) {
+	for k, v := range n.board.CharacterToPlayer {
+		rules := characterRules(k)
+		if rules == nil {
+			continue
+		}
+		if (rules.Loyalty == BAD && k != n.character && !rules.HiddenFromEvil) || rules.SeenByEvil {
+			if k == Polygraph {
+				n.reveal(v.Player, v.Player+" is polygraph", Polygraph)
+			} else if v.Player == n.strayPlayer.Player {
+				n.uncover(v.Player, Stray)
+			} else {
+				n.uncoverBad(v.Player)
 			}
 		}
-		whoSeeWho[character] = mapp
 	}
-	if character == Balain {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
+	if _, ok := n.board.isCharacterExists(true, Stray); ok && n.strayPlayer != n.player {
+		if !n.sees(n.strayPlayer.Player) {
+			n.uncover(n.strayPlayer.Player, Stray)
 		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == Balin {
-				secrets = append(secrets, v.Player+" is Balin")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Balin
-				mapp[v.Player] = true
-			}
-		}
-		whoSeeWho[character] = mapp
 	}
-	if character == PrinceClaudin {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == KingClaudin {
-				secrets = append(secrets, v.Player+" is King-Claudin")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = KingClaudin
-				mapp[v.Player] = true
+}
+
+func merlinNight(n *night) {
+	for k, v := range n.board.CharacterToPlayer {
+		if _, ok := badCharacters[k]; ok && k != Mordred && k != Accolon {
+			if k == Oberon {
+				n.uncover(v.Player, Oberon)
+			} else {
+				n.uncoverBad(v.Player)
 			}
 		}
-		whoSeeWho[character] = mapp
-	}
-	if character == KingClaudin {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
+		if k == Stray || k == Lot || k == Gawain {
+			n.uncover(v.Player, k)
 		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == PrinceClaudin {
-				secrets = append(secrets, v.Player+" is Prince-Claudin")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = PrinceClaudin
-				mapp[v.Player] = true
-			}
+		if k == Meliagant || k == Ginerva || k == SirKay {
+			n.uncoverBad(v.Player)
 		}
-		whoSeeWho[character] = mapp
 	}
+}
 
-	if character == MerlinApprentice {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == Percival {
-				secrets = append(secrets, v.Player+" is Percival/Assasin")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = "PercivalAssasin"
-				mapp[v.Player] = true
-			}
-			if k == Assassin {
-				secrets = append(secrets, v.Player+" is Percival/Assassin")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = "PercivalAssasin"
-				mapp[v.Player] = true
+func meliagantNight(n *night) {
+	for k, v := range n.board.CharacterToPlayer {
+		if _, ok := badCharacters[k]; ok {
+			if v.Player == n.strayPlayer.Player {
+				n.uncover(v.Player, Stray)
+			} else {
+				n.uncover(v.Player, k)
 			}
+		} else if k == Lot {
+			n.uncover(v.Player, Lot)
 		}
-		whoSeeWho[character] = mapp
 	}
-	if character == Tristan {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == Iseult {
-				secrets = append(secrets, v.Player+" is Iseult")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Iseult
-				mapp[v.Player] = true
-			}
-		}
-		whoSeeWho[character] = mapp
-	}
-	if character == Lot {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if _, ok := badCharacters[k]; (ok && k != character && k != Oberon && k != Accolon) || k == Meliagant {
-				if k == "Polygraph" {
-					secrets = append(secrets, v.Player+" is polygraph")
-					playerSecret.PlayersWithUncoveredCharacters[v.Player] = "Polygraph"
-					mapp[v.Player] = true
-				} else {
-					secrets = append(secrets, v.Player+" is bad")
-					playerSecret.PlayersWithBadCharacter = append(playerSecret.PlayersWithBadCharacter, v.Player)
-					mapp[v.Player] = true
-				}
-			}
+}
+
+func guinevereNight(n *night) {
+	for _, k := range []string{LancelotGood, LancelotBad} {
+		if v, ok := n.board.CharacterToPlayer[k]; ok {
+			n.reveal(v.Player, v.Player+" is Lancelot", "Lancelot")
 		}
-		whoSeeWho[character] = mapp
 	}
-	if character == Nimue {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == Galahad {
-				secrets = append(secrets, v.Player+" is Galahad")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Galahad
-				mapp[v.Player] = true
-			}
-			if k == Merlin {
-				secrets = append(secrets, v.Player+" is Merlin")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Merlin
-				mapp[v.Player] = true
-			}
-		}
-		whoSeeWho[character] = mapp
+}
+
+func merlinApprenticeNight(n *night) {
+	if v, ok := n.board.CharacterToPlayer[Percival]; ok {
+		n.reveal(v.Player, v.Player+" is Percival/Assasin", "PercivalAssasin")
 	}
-	if character == Nerzhul {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == Oberon {
-				secrets = append(secrets, v.Player+" is Oberon")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Oberon
-				mapp[v.Player] = true
-			}
-		}
-		whoSeeWho[character] = mapp
+	if v, ok := n.board.CharacterToPlayer[Assassin]; ok {
+		n.reveal(v.Player, v.Player+" is Percival/Assassin", "PercivalAssasin")
 	}
-	if character == Dagonet {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == Oberon {
-				secrets = append(secrets, v.Player+" is Oberon")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = Oberon
-				mapp[v.Player] = true
+}
+
+func lotNight(n *night) {
+	for k, v := range n.board.CharacterToPlayer {
+		if _, ok := badCharacters[k]; (ok && k != n.character && k != Oberon && k != Accolon) || k == Meliagant {
+			if k == Polygraph {
+				n.reveal(v.Player, v.Player+" is polygraph", Polygraph)
+			} else {
+				n.uncoverBad(v.Player)
 			}
 		}
-		whoSeeWho[character] = mapp
 	}
-	if character == Morgana {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
+}
+
+func percivalNight(n *night) {
+	_, hasMerlin := n.board.CharacterToPlayer[Merlin]
+	_, hasGoodSorcerer := n.board.CharacterToPlayer[GoodSorcerer]
+	_, hasEvilSorcerer := n.board.CharacterToPlayer[EvilSorcerer]
+	hasSorcerers := hasGoodSorcerer && hasEvilSorcerer
+	for k, v := range n.board.CharacterToPlayer {
+		if (k == Morgana || k == Viviana) && !hasMerlin {
+			n.reveal(v.Player, v.Player+" is Morgana/Viviana", "MorganaViviana")
 		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == "Gawain" {
-				secrets = append(secrets, v.Player+" is Gawain")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = "Gawain"
-				mapp[v.Player] = true
-			}
+		if (k == Morgana && hasMerlin) || k == Merlin {
+			n.reveal(v.Player, v.Player+" is Morgana/Merlin", "MorganaMerlin")
 		}
-		whoSeeWho[character] = mapp
-	}
-	if character == Percival {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if k == Morgana {
-				if _, ok := globalBoard.CharacterToPlayer[Merlin]; !ok {
-					secrets = append(secrets, v.Player+" is Morgana/Viviana")
-					playerSecret.PlayersWithUncoveredCharacters[v.Player] = "MorganaViviana"
-					mapp[v.Player] = true
-				} else {
-					secrets = append(secrets, v.Player+" is Morgana/Merlin")
-					playerSecret.PlayersWithUncoveredCharacters[v.Player] = "MorganaMerlin"
-					mapp[v.Player] = true
-				}
-			}
-			if k == Merlin {
-				secrets = append(secrets, v.Player+" is Morgana/Merlin")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = "MorganaMerlin"
-				mapp[v.Player] = true
-			}
-			if k == Viviana {
-				if _, ok := globalBoard.CharacterToPlayer[Merlin]; !ok {
-					secrets = append(secrets, v.Player+" is Morgana/Viviana")
-					playerSecret.PlayersWithUncoveredCharacters[v.Player] = "MorganaViviana"
-					mapp[v.Player] = true
-				}
-			}
-
+		if (k == GoodSorcerer || k == EvilSorcerer) && hasSorcerers {
+			n.reveal(v.Player, v.Player+" is a Sorcerer", "Sorcerer")
 		}
-		whoSeeWho[character] = mapp
 	}
+}
 
-	if character == Claudas {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		if oberonPlayer, exists := isCharacterExists(true, Oberon); exists {
-			playerSecret.PlayersWithUncoveredCharacters[oberonPlayer.Player] = Oberon
-			mapp[oberonPlayer.Player] = true
+// Claudas knows Oberon and Sir Kay, without a secret line.
+func claudasNight(n *night) {
+	for _, c := range []string{Oberon, SirKay} {
+		if v, exists := n.board.isCharacterExists(true, c); exists {
+			n.secret.PlayersWithUncoveredCharacters[v.Player] = c
+			n.see(v.Player)
 		}
-		if sirkayPlayer, exists := isCharacterExists(true, SirKay); exists {
-			playerSecret.PlayersWithUncoveredCharacters[sirkayPlayer.Player] = SirKay
-			mapp[sirkayPlayer.Player] = true
-		}
-		whoSeeWho[character] = mapp
 	}
+}
 
-
-
-	if _, ok := badCharacters[character]; ok && character != Oberon && character != Accolon && character != LancelotBad && character != Balin && character != Agravain {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
+// Gawain sees the bads and the special goods, without knowing which is which.
+func gawainNight(n *night) {
+	for k, v := range n.board.CharacterToPlayer {
+		if _, ok := badCharacters[k]; (ok && k != n.character && k != Oberon && k != Accolon) || k == Meliagant ||
+			k == Percival || k == Merlin || k == Nirlem || k == Viviana {
+			n.reveal(v.Player, v.Player+" ", "Unknown")
 		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if _, ok := badCharacters[k]; (ok && k != character && k != Oberon && k != Accolon && k != Agravain) || k == Meliagant {
-				if k == "Polygraph" {
-					secrets = append(secrets, v.Player+" is polygraph")
-					playerSecret.PlayersWithUncoveredCharacters[v.Player] = "Polygraph"
-					mapp[v.Player] = true
-				} else {
+	}
+}
 
-					if v.Player == strayPlayer.Player {
-						secrets = append(secrets, v.Player+" is Stray")
-						playerSecret.PlayersWithUncoveredCharacters[v.Player] = Stray
-					} else {
-						secrets = append(secrets, v.Player+" is bad")
-						playerSecret.PlayersWithBadCharacter = append(playerSecret.PlayersWithBadCharacter, v.Player)
-					}
-					mapp[v.Player] = true
-				}
-			}
-		}
-		if _, ok := isCharacterExists(true, Stray); ok && strayPlayer != player {
-			if !mapp[strayPlayer.Player] {
-				secrets = append(secrets, strayPlayer.Player+" is Stray")
-				playerSecret.PlayersWithUncoveredCharacters[strayPlayer.Player] = Stray
-				mapp[strayPlayer.Player] = true
-			}
+// Gornemant learns two players of the same team, and two players of different teams.
+func gornemantNight(n *night) {
+	board := n.board
+	bads := make([]string, 0)
+	goods := make([]string, 0)
+	for _, c := range board.Characters {
+		if c == Stray {
+			c = board.PlayerToCharacter[n.strayPlayer]
+		}
+		if _, ok := goodCharacters[c]; ok {
+			goods = append(goods, c)
+		} else {
+			bads = append(bads, c)
 		}
-		whoSeeWho[character] = mapp
 	}
+	sameTeamTakenFromBads := board.rng.Intn(2)
+	var sameTeam []string
+	var notSameTeam []string
+	if sameTeamTakenFromBads == 1 {
+		sameTeam = bads
+		notSameTeam = goods
+		log.Println("sameTeamTakenFromBads == 1")
+	} else {
+		sameTeam = goods
+		notSameTeam = bads
+	}
+	log.Println("sameTeam =", sameTeam, "len=", len(sameTeam))
+	log.Println("notSameTeam =", notSameTeam, "len=", len(notSameTeam))
 
-	if character == TheQuestingBeast {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		pellinore, ok := globalBoard.CharacterToPlayer[Pellinore]
-		if ok {
-			secrets = append(secrets, pellinore.Player+" is Pellinore")
-			playerSecret.PlayersWithUncoveredCharacters[pellinore.Player] = Pellinore
-			mapp[pellinore.Player] = true
-		}
+	sameTeam = removeElementFromSlice(n.player, sameTeam)
 
-		whoSeeWho[character] = mapp
-	}
+	notSameTeam = removeElementFromSlice(n.player, notSameTeam)
 
-	if character == "Gawain" {
-		mapp := whoSeeWho[character]
-		if mapp == nil {
-			mapp = make(map[string]bool)
-		}
-		for k, v := range globalBoard.CharacterToPlayer {
-			if _, ok := badCharacters[k]; (ok && k != character && k != Oberon && k != Accolon) || k == Meliagant {
-				secrets = append(secrets, v.Player+" ")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = "Unknown"
-				mapp[v.Player] = true
-			}
-			if k == Percival || k == Merlin || k == Nirlem || k == Viviana {
-				secrets = append(secrets, v.Player+" ")
-				playerSecret.PlayersWithUncoveredCharacters[v.Player] = "Unknown"
-				mapp[v.Player] = true
-			}
-		}
-		whoSeeWho[character] = mapp
+	random1 := board.rng.Intn(len(sameTeam))
+	random2 := board.rng.Intn(len(sameTeam))
+	Player1 := board.CharacterToPlayer[sameTeam[random1]].Player
+	Player2 := board.CharacterToPlayer[sameTeam[random2]].Player
+	if random2 == random1 {
+		random2 = (random2 + 1) % len(sameTeam)
+		Player2 = board.CharacterToPlayer[sameTeam[random2]].Player
 	}
 
-	rand.Seed(int64(time.Now().Nanosecond()))
-	rand.Shuffle(len(secrets), func(i, j int) {
-		secrets[i], secrets[j] = secrets[j], secrets[i]
-	})
+	log.Println("random1 =", random1, " random2 = ", random2)
 
-	rand.Seed(int64(time.Now().Nanosecond()))
-	rand.Shuffle(len(playerSecret.PlayersWithBadCharacter), func(i, j int) {
-		playerSecret.PlayersWithBadCharacter[i], playerSecret.PlayersWithBadCharacter[j] = playerSecret.PlayersWithBadCharacter[j], playerSecret.PlayersWithBadCharacter[i]
-	})
+	n.secrets = append(n.secrets, Player1+" and "+Player2)
+	n.secret.PlayersWithSameLoyalty = []string{Player1, Player2}
 
-	rand.Seed(int64(time.Now().Nanosecond()))
-	rand.Shuffle(len(playerSecret.PlayersWithGoodCharacter), func(i, j int) {
-		playerSecret.PlayersWithGoodCharacter[i], playerSecret.PlayersWithGoodCharacter[j] = playerSecret.PlayersWithGoodCharacter[j], playerSecret.PlayersWithBadCharacter[i]
-	})
+	sameTeam = removeElementFromStringSlice(sameTeam, random1)
 
-	log.Println("character:", character)
-	log.Println("secrets:", secrets)
-	log.Println("new secrets:", playerSecret)
-	return &playerSecret, secrets, whoSeeWho
+	idx := 0
+	isFound := false
+	for i := range sameTeam {
+		if Player2 != board.CharacterToPlayer[sameTeam[i]].Player {
+			idx++
+		} else {
+			isFound = true
+			break
+		}
+	}
+	if isFound {
+		sameTeam = removeElementFromStringSlice(sameTeam, idx)
+	}
+
+	log.Println("new sameTeam =", sameTeam)
+	random3 := board.rng.Intn(len(notSameTeam))
+	random4 := board.rng.Intn(len(sameTeam))
+	log.Println("random3 =", random3, " random4 = ", random4)
+	Player3 := board.CharacterToPlayer[notSameTeam[random3]].Player
+	Player4 := board.CharacterToPlayer[sameTeam[random4]].Player
+
+	n.secrets = append(n.secrets, Player3+" and "+Player4)
+	n.secret.PlayersWithDifferentLoyalty = []string{Player3, Player4}
 }
 
 func removeElementFromSlice(player PlayerName, sameTeam []string) []string {
@@ -923,7 +771,7 @@ func removeElementFromSlice(player PlayerName, sameTeam []string) []string {
 }
 
 
-func assignCharactersToRegisteredPlayers(newGameConfig []Ch, chosenCharacters []string) ([]string, string) {
+func (board *BoardGame) assignCharactersToRegisteredPlayers(newGameConfig []Ch, chosenCharacters []string) ([]string, string) {
 	var assassinCharacter string
 	var hasStray bool
 	for _, v := range newGameConfig {
@@ -938,48 +786,61 @@ func assignCharactersToRegisteredPlayers(newGameConfig []Ch, chosenCharacters []
 		}
 	}
 
-	rand.Seed(int64(time.Now().Nanosecond()))
-	rand.Shuffle(len(chosenCharacters), func(i, j int) {
+	board.rng.Shuffle(len(chosenCharacters), func(i, j int) {
 		chosenCharacters[i], chosenCharacters[j] = chosenCharacters[j], chosenCharacters[i]
 	})
 
-	globalBoard.PlayerToCharacter = make(map[PlayerName]string)
-	globalBoard.CharacterToPlayer = make(map[string]PlayerName)
+	board.PlayerToCharacter = make(map[PlayerName]string)
+	board.CharacterToPlayer = make(map[string]PlayerName)
 
-	for i := 0; i < len(globalBoard.PlayerNames); i++ {
-		globalBoard.PlayerToCharacter[globalBoard.PlayerNames[i]] = chosenCharacters[i]
-		globalBoard.CharacterToPlayer[chosenCharacters[i]] = globalBoard.PlayerNames[i]
+	for i := 0; i < len(board.PlayerNames); i++ {
+		board.PlayerToCharacter[board.PlayerNames[i]] = chosenCharacters[i]
+		board.CharacterToPlayer[chosenCharacters[i]] = board.PlayerNames[i]
 	}
 
 
 	if hasStray {
-		strayPlayer := globalBoard.CharacterToPlayer[Stray]
+		strayPlayer := board.CharacterToPlayer[Stray]
 		goodChars := make([]string, 0)
 		for _, c := range optionalGoodsForStray {
-			if _, ok := globalBoard.CharacterToPlayer[c]; !ok {
-				if len(globalBoard.PlayerNames) >= 7 || !notAllowedGoodsForStrayForLessThan7Players[c] {
+			if _, ok := board.CharacterToPlayer[c]; !ok {
+				if len(board.PlayerNames) >= 7 || !notAllowedGoodsForStrayForLessThan7Players[c] {
 					goodChars = append(goodChars, c)
 				}
 			}
 		}
-		rand.Seed(int64(time.Now().Nanosecond()))
-		rand.Shuffle(len(goodChars), func(i, j int) {
+		board.rng.Shuffle(len(goodChars), func(i, j int) {
 			goodChars[i], goodChars[j] = goodChars[j], goodChars[i]
 		})
-		random1 := rand.Intn(len(goodChars))
+		random1 := board.rng.Intn(len(goodChars))
 
 		newCharactersForStray := []string{Mordred, goodChars[random1]}
-		random2 := rand.Intn(len(newCharactersForStray))
-		if _, ok := isCharacterExists(true, Mordred); ok {
+		random2 := board.rng.Intn(len(newCharactersForStray))
+		if _, ok := board.isCharacterExists(true, Mordred); ok {
 			random2 = 1
 		}
-		globalBoard.PlayerToCharacter[strayPlayer] = newCharactersForStray[random2]
-		globalBoard.CharacterToPlayer[newCharactersForStray[random2]] = strayPlayer
-		//globalBoard.Characters = append(globalBoard.Characters, newCharactersForStray[random2])
 		//so we have character['stray'] --> playerX and playerX --> NEW CHARACTER
+		board.changeIdentities(IdentityStray, 0, identitySwap{strayPlayer, newCharactersForStray[random2]})
 	}
-	if _, ok := globalBoard.CharacterToPlayer[Assassin]; ok {
+	if _, ok := board.CharacterToPlayer[Assassin]; ok {
 		assassinCharacter = Assassin
 	}
-	return chosenCharacters, globalBoard.CharacterToPlayer[assassinCharacter].Player
+	return chosenCharacters, board.CharacterToPlayer[assassinCharacter].Player
+}
+
+// The Cleric learns the loyalty the first quest leader shows to loyalty checks.
+func clericNight(n *night) {
+	leader := n.board.PlayerNames[n.board.suggestions.suggesterIndex%len(n.board.PlayerNames)].Player
+	if leader == n.player.Player {
+		return
+	}
+	switch n.board.inspectedLoyalty(leader) {
+	case GOOD:
+		n.uncoverGood(leader)
+	case BAD:
+		n.uncoverBad(leader)
+	default:
+		n.secrets = append(n.secrets, leader+" is neutral")
+		n.see(leader)
+	}
 }
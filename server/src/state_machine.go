@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// Who may issue a command in a state.
+const (
+	IssuerAnyone          = "anyone"
+	IssuerSeated          = "seated"
+	IssuerSuggester       = "suggester"
+	IssuerQuestMember     = "quest_member"
+	IssuerLadyHolder      = "lady_holder"
+	IssuerLadyChosen      = "lady_chosen"
+	IssuerExcaliburHolder = "excalibur_holder"
+	IssuerSeer            = "seer"
+	IssuerMurderer        = "murderer"
+)
+
+// StateSpec is one game phase and the commands (mapped to their issuer) that are legal in it.
+type StateSpec struct {
+	State    int               `json:"state"`
+	Name     string            `json:"name"`
+	Commands map[string]string `json:"commands"`
+	Final    bool              `json:"final,omitempty"`
+}
+
+type Transition struct {
+	From    int    `json:"from"`
+	To      int    `json:"to"`
+	Command string `json:"command"`
+}
+
+/*
+StateMachine declares the game phases. Commands in Global are legal in every state.
+Every other command has the transitions it may take out of a state, a transition to the same state when it may
+leave the phase as it is. Handlers move the board only along Transitions (see BoardGame.setState).
+*/
+type StateMachine struct {
+	States      []StateSpec       `json:"states"`
+	Global      map[string]string `json:"global"`
+	Transitions []Transition      `json:"transitions"`
+}
+
+var gameMachine = StateMachine{
+	States: []StateSpec{
+		{NotStarted, "NotStarted", map[string]string{"add_player": IssuerAnyone, "start_game": IssuerSeated}, false},
+		{SirPickPlayer, "SirPickPlayer", map[string]string{"sir_pick": IssuerSeer}, false},
+		{WaitingForSuggestion, "WaitingForSuggestion", map[string]string{"suggestion": IssuerSuggester, "suggestion_tmp": IssuerSuggester, "plot_deal": IssuerSuggester, "plot_play": IssuerSeated, "messenger_action": IssuerSeated}, false},
+		{SuggestionVoting, "SuggestionVoting", map[string]string{"vote_for_suggestion": IssuerSeated}, false},
+		{JorneyVoting, "JorneyVoting", map[string]string{"vote_for_journey": IssuerQuestMember, "plot_play": IssuerSeated}, false},
+		{ExcaliburPick, "ExcaliburPick", map[string]string{"excalibur_pick": IssuerExcaliburHolder}, false},
+		{VictoryForGood, "VictoryForGood", map[string]string{}, true},
+		{VictoryForBad, "VictoryForBad", map[string]string{}, true},
+		{MurdersAfterGoodVictory, "MurdersAfterGoodVictory", map[string]string{"murder": IssuerMurderer, "declare_target": IssuerMurderer}, false},
+		{MurdersAfterBadVictory, "MurdersAfterBadVictory", map[string]string{"murder": IssuerMurderer}, false},
+		{VictoryForGawain, "VictoryForGawain", map[string]string{}, true},
+		{WaitingForLadySuggester, "WaitingForLadySuggester", map[string]string{"lady_suggest": IssuerLadyHolder}, false},
+		{LadyResponse, "LadyResponse", map[string]string{"lady_response": IssuerLadyChosen}, false},
+		{LadySuggesterPublishResponseToWorld, "LadySuggesterPublishResponseToWorld", map[string]string{"lady_publish_response": IssuerLadyHolder}, false},
+		{VictoryForSirGawain, "VictoryForSirGawain", map[string]string{}, true},
+	},
+	Global: map[string]string{"reset": IssuerAnyone},
+	Transitions: []Transition{
+		{NotStarted, NotStarted, "add_player"},
+		{NotStarted, SirPickPlayer, "start_game"},
+		{NotStarted, WaitingForSuggestion, "start_game"},
+		{SirPickPlayer, WaitingForSuggestion, "sir_pick"},
+		{WaitingForSuggestion, WaitingForSuggestion, "suggestion_tmp"},
+		{WaitingForSuggestion, WaitingForSuggestion, "plot_deal"},
+		{WaitingForSuggestion, WaitingForSuggestion, "plot_play"},
+		{WaitingForSuggestion, WaitingForSuggestion, "messenger_action"},
+		{WaitingForSuggestion, SuggestionVoting, "suggestion"},
+		{WaitingForSuggestion, JorneyVoting, "suggestion"}, // hammer
+		{WaitingForSuggestion, VictoryForGawain, "suggestion"},
+		{SuggestionVoting, SuggestionVoting, "vote_for_suggestion"},
+		{SuggestionVoting, JorneyVoting, "vote_for_suggestion"},
+		{SuggestionVoting, WaitingForSuggestion, "vote_for_suggestion"},
+		{SuggestionVoting, VictoryForGawain, "vote_for_suggestion"},
+		{SuggestionVoting, VictoryForBad, "vote_for_suggestion"}, // rejected without a hammer
+		{SuggestionVoting, MurdersAfterBadVictory, "vote_for_suggestion"},
+		{JorneyVoting, JorneyVoting, "vote_for_journey"},
+		{JorneyVoting, ExcaliburPick, "vote_for_journey"},
+		{JorneyVoting, WaitingForSuggestion, "vote_for_journey"},
+		{JorneyVoting, WaitingForLadySuggester, "vote_for_journey"},
+		{JorneyVoting, VictoryForGood, "vote_for_journey"},
+		{JorneyVoting, VictoryForBad, "vote_for_journey"},
+		{JorneyVoting, MurdersAfterGoodVictory, "vote_for_journey"},
+		{JorneyVoting, MurdersAfterBadVictory, "vote_for_journey"},
+		{JorneyVoting, JorneyVoting, "plot_play"},
+		{JorneyVoting, WaitingForSuggestion, "plot_play"}, // no confidence
+		{ExcaliburPick, WaitingForSuggestion, "excalibur_pick"},
+		{ExcaliburPick, WaitingForLadySuggester, "excalibur_pick"},
+		{ExcaliburPick, VictoryForGood, "excalibur_pick"},
+		{ExcaliburPick, VictoryForBad, "excalibur_pick"},
+		{ExcaliburPick, MurdersAfterGoodVictory, "excalibur_pick"},
+		{ExcaliburPick, MurdersAfterBadVictory, "excalibur_pick"},
+		{WaitingForLadySuggester, LadyResponse, "lady_suggest"},
+		{LadyResponse, LadySuggesterPublishResponseToWorld, "lady_response"},
+		{LadyResponse, WaitingForSuggestion, "lady_response"}, // public result
+		{LadySuggesterPublishResponseToWorld, WaitingForSuggestion, "lady_publish_response"},
+		{MurdersAfterGoodVictory, MurdersAfterGoodVictory, "declare_target"},
+		{MurdersAfterGoodVictory, MurdersAfterGoodVictory, "murder"},
+		{MurdersAfterGoodVictory, VictoryForGood, "murder"},
+		{MurdersAfterGoodVictory, VictoryForBad, "murder"},
+		{MurdersAfterGoodVictory, VictoryForSirGawain, "murder"},
+		{MurdersAfterBadVictory, MurdersAfterBadVictory, "murder"},
+		{MurdersAfterBadVictory, MurdersAfterGoodVictory, "murder"},
+		{MurdersAfterBadVictory, VictoryForGood, "murder"},
+		{MurdersAfterBadVictory, VictoryForBad, "murder"},
+		{MurdersAfterBadVictory, VictoryForSirGawain, "murder"},
+	},
+}
+
+func (machine *StateMachine) spec(state int) (StateSpec, bool) {
+	for _, s := range machine.States {
+		if s.State == state {
+			return s, true
+		}
+	}
+	return StateSpec{}, false
+}
+
+func (machine *StateMachine) stateName(state int) string {
+	if s, ok := machine.spec(state); ok {
+		return s.Name
+	}
+	return strconv.Itoa(state)
+}
+
+// governs tells whether the machine decides when command is legal.
+func (machine *StateMachine) governs(command string) bool {
+	if _, ok := machine.Global[command]; ok {
+		return true
+	}
+	for _, s := range machine.States {
+		if _, ok := s.Commands[command]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// leaves tells whether command has a transition out of from, to the same state included.
+func (machine *StateMachine) leaves(from int, command string) bool {
+	for _, t := range machine.Transitions {
+		if t.From == from && t.Command == command {
+			return true
+		}
+	}
+	return false
+}
+
+func (machine *StateMachine) allowsTransition(from, to int) bool {
+	if from == to {
+		return true
+	}
+	for _, t := range machine.Transitions {
+		if t.From == from && t.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCommand rejects a command that is not legal in the current state, that has no transition out of it, or that
+// player may not issue. It runs before the handler, so a rejected command leaves the board as it is.
+// The lock must be held.
+func (board *BoardGame) checkCommand(command string, player string) error {
+	issuer, ok := gameMachine.Global[command]
+	if !ok {
+		spec, _ := gameMachine.spec(board.State)
+		issuer, ok = spec.Commands[command]
+		if !ok || !gameMachine.leaves(board.State, command) {
+			return newCommandError(ErrWrongState, command+" is not allowed in "+gameMachine.stateName(board.State))
+		}
+	}
+	return board.checkIssuer(issuer, player)
+}
+
+func (board *BoardGame) checkIssuer(issuer string, player string) error {
+	switch issuer {
+	case IssuerAnyone:
+		return nil
+	case IssuerSeated:
+		if !board.isSeated(player) {
+			return newCommandError(ErrNotSeated, player+" is not seated")
+		}
+		return nil
+	case IssuerQuestMember:
+		for _, member := range board.suggestions.SuggestedPlayers {
+			if member == player {
+				return nil
+			}
+		}
+		return newCommandError(ErrNotOnQuest, player+" is not on this quest")
+	}
+
+	var expected string
+	switch issuer {
+	case IssuerSuggester:
+		if len(board.PlayerNames) > 0 {
+			expected = board.PlayerNames[board.suggestions.suggesterIndex%len(board.PlayerNames)].Player
+		}
+	case IssuerLadyHolder:
+		expected = board.ladyOfTheLake.currentSuggester
+	case IssuerLadyChosen:
+		expected = board.ladyOfTheLake.currentChosenPlayer
+	case IssuerExcaliburHolder:
+		expected = board.suggestions.excalibur.Player
+		if expected == "" { // nobody got excalibur, the suggester moves the game on
+			expected = board.suggestions.excalibur.Suggester
+		}
+	case IssuerSeer:
+		expected = board.CharacterToPlayer[Seer].Player
+	case IssuerMurderer:
+		if len(board.PendingMurders) > 0 {
+			expected = board.PendingMurders[0].By
+		}
+	}
+	if expected == "" || expected != player {
+		return newCommandError(ErrNotYourTurn, "only the "+issuer+" ("+expected+") can do this now")
+	}
+	return nil
+}
+
+/*
+setState moves the board to a new phase. Commands are checked against the table before their handler runs (see
+checkCommand), so a move that is not in it is a bug of the table, not of the client: it is logged and kept as an
+internal error, and the board still moves, since the handler already updated the rest of it for the new phase.
+*/
+func (board *BoardGame) setState(to int) {
+	if !gameMachine.allowsTransition(board.State, to) {
+		err := newCommandError(ErrInternal, "undeclared state transition: "+gameMachine.stateName(board.State)+" -> "+
+			gameMachine.stateName(to))
+		log.Println(err)
+		board.transitionErr = err
+	}
+	board.State = to
+}
+
+// takeTransitionError returns and clears the undeclared transition taken since it was last called.
+func (board *BoardGame) takeTransitionError() error {
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
+	if board.transitionErr == nil {
+		return nil
+	}
+	err := board.transitionErr
+	board.transitionErr = nil
+	return err
+}
+
+// DOT renders the machine for Graphviz.
+func (machine *StateMachine) DOT() string {
+	var buf bytes.Buffer
+	buf.WriteString("digraph avalon {\n\trankdir=LR;\n")
+	for _, s := range machine.States {
+		commands := make([]string, 0, len(s.Commands))
+		for command, issuer := range s.Commands {
+			commands = append(commands, command+" ("+issuer+")")
+		}
+		sort.Strings(commands)
+		shape := "box"
+		if s.Final {
+			shape = "doubleoctagon"
+		}
+		label := s.Name
+		for _, c := range commands {
+			label += "\\n" + c
+		}
+		fmt.Fprintf(&buf, "\t%s [shape=%s, label=\"%s\"];\n", s.Name, shape, label)
+	}
+	for _, t := range machine.Transitions {
+		fmt.Fprintf(&buf, "\t%s -> %s [label=\"%s\"];\n", machine.stateName(t.From), machine.stateName(t.To), t.Command)
+	}
+	globals := make([]string, 0, len(machine.Global))
+	for command := range machine.Global {
+		globals = append(globals, command)
+	}
+	sort.Strings(globals)
+	if len(globals) > 0 {
+		buf.WriteString("\tany [shape=plaintext, label=\"any state\"];\n")
+	}
+	for _, command := range globals {
+		fmt.Fprintf(&buf, "\tany -> %s [label=\"%s\", style=dashed];\n", machine.stateName(NotStarted), command)
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// stateMachinePage serves the machine as JSON, or as Graphviz DOT with ?format=dot.
+func stateMachinePage(res http.ResponseWriter, req *http.Request) {
+	if req.URL.Query().Get("format") == "dot" {
+		res.Header().Add("Content-Type", "text/vnd.graphviz")
+		res.Write([]byte(gameMachine.DOT()))
+		return
+	}
+	res.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(&gameMachine)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_StateMachine(t *testing.T) {
+	t.Run("Declares every state used by a transition", should_declare_every_transition_state)
+	t.Run("Declares a transition for every command of a state", should_declare_transition_for_every_command)
+	t.Run("Rejects a command that is not legal in the current state", should_reject_command_illegal_in_state)
+	t.Run("Rejects a command from a player that may not issue it", should_reject_command_from_wrong_issuer)
+	t.Run("Exports the machine as Graphviz DOT", should_export_dot)
+	t.Run("Reports a transition that is not declared as an internal error", should_report_undeclared_transition)
+}
+
+func should_declare_every_transition_state(t *testing.T) {
+	//Arrange
+	machine := gameMachine
+
+	//Act
+	missing := make([]int, 0)
+	for _, transition := range machine.Transitions {
+		if _, ok := machine.spec(transition.From); !ok {
+			missing = append(missing, transition.From)
+		}
+		if _, ok := machine.spec(transition.To); !ok {
+			missing = append(missing, transition.To)
+		}
+	}
+
+	//Assert
+	if len(missing) != 0 {
+		t.Error("Transitions use undeclared states:", missing)
+	}
+}
+
+func should_declare_transition_for_every_command(t *testing.T) {
+	//Arrange
+	machine := gameMachine
+
+	//Act
+	missing := make([]string, 0)
+	for _, spec := range machine.States {
+		for command := range spec.Commands {
+			if !machine.leaves(spec.State, command) {
+				missing = append(missing, spec.Name+" "+command)
+			}
+		}
+	}
+	for _, transition := range machine.Transitions {
+		if spec, _ := machine.spec(transition.From); spec.Commands[transition.Command] == "" {
+			missing = append(missing, machine.stateName(transition.From)+" "+transition.Command)
+		}
+	}
+
+	//Assert
+	if len(missing) != 0 {
+		t.Error("Commands and transitions should match, got", missing)
+	}
+}
+
+func should_reject_command_illegal_in_state(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{Lady: true, LadyOptions: &LadyConfiguration{FirstHolder: "alice"}}, classicRoles...)
+	c := &Client{id: "alice", room: board, send: newSendQueue(10), patch: newPatchState(false)}
+	envelope, _ := parseEnvelope([]byte(`{"v":1,"type":"lady_suggest","payload":"bob"}`))
+
+	//Act
+	err := c.handleCommand(envelope)
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrWrongState {
+		t.Error("Expected wrong_state, got", err)
+	}
+	if board.State != WaitingForSuggestion {
+		t.Error("State should not change, got", board.State)
+	}
+}
+
+func should_reject_command_from_wrong_issuer(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, Seer, Assassin, Percival, Morgana, LoyalServentOfArthur)
+	if board.State != SirPickPlayer {
+		t.Fatal("The Seer should pick first, got", board.State)
+	}
+
+	//Act
+	err := board.checkCommand("sir_pick", playerOf(board, Percival))
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrNotYourTurn {
+		t.Error("Expected not_your_turn, got", err)
+	}
+}
+
+func should_export_dot(t *testing.T) {
+	//Arrange
+	machine := gameMachine
+
+	//Act
+	dot := machine.DOT()
+
+	//Assert
+	if !strings.HasPrefix(dot, "digraph") || !strings.Contains(dot, "JorneyVoting -> ExcaliburPick") {
+		t.Error("Expected a digraph with the quest transitions, got", dot)
+	}
+}
+
+func should_report_undeclared_transition(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+
+	//Act
+	board.setState(VictoryForGood)
+	err := board.takeTransitionError()
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInternal {
+		t.Error("Expected internal_error, got", err)
+	}
+	if board.takeTransitionError() != nil {
+		t.Error("The error should be taken once")
+	}
+}
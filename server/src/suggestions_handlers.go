@@ -13,47 +13,35 @@ type Suggestion struct {
 }
 
 
-type SuggestMessage struct {
-	Tp      string     `json:"type"`
-	Content Suggestion `json:"content"`
-}
-
-
-type SuggestTmpMessage struct {
-	Tp      string   `json:"type"`
-	Content []string `json:"content"`
-}
-
-type VoteForSuggestionMessage struct {
-	Tp      string            `json:"type"`
-	Content VoteForSuggestion `json:"content"`
-}
-
-
 type VoteForSuggestion struct {
 	PlayerName string `json:"playerName"`
 	Vote       bool   `json:"vote"`
 }
 
-func HandleNewSuggest(pl Suggestion) {
-	globalMutex.Lock()
+func (board *BoardGame) HandleNewSuggest(pl Suggestion) error {
+	board.mutex.Lock()
+	if err := board.validateSuggestion(pl); err != nil {
+		board.mutex.Unlock()
+		return err
+	}
 	suggestedPlayers := pl.Players
 	suggestedCharacters := make(map[string]bool, 0)
 
 	for _, v := range pl.Players {
-		suggestedCharacters[globalBoard.PlayerToCharacter[PlayerName{v}]] = true
+		suggestedCharacters[board.PlayerToCharacter[PlayerName{v}]] = true
 	}
 
-	suggesterIn := globalBoard.suggestions.suggesterIndex % len(globalBoard.PlayerNames)
-	newEntry := QuestArchiveItem{Id: globalBoard.QuestStage, Suggester: globalBoard.PlayerNames[suggesterIn], SuggestedPlayers: suggestedPlayers, ExcaliburPlayer: pl.ExcaliburPlayer}
+	suggesterIn := board.suggestions.suggesterIndex % len(board.PlayerNames)
+	newEntry := QuestArchiveItem{Id: board.QuestStage, Suggester: board.PlayerNames[suggesterIn], SuggestedPlayers: suggestedPlayers, ExcaliburPlayer: pl.ExcaliburPlayer,
+		PlotEvents: board.takePlotEvents()}
 
-	log.Println("SuggestedPlayers:", suggestedPlayers, ",ExcaliburPlayer:", pl.ExcaliburPlayer, ",Suggester:", globalBoard.PlayerNames[suggesterIn].Player)
-	globalBoard.suggestions.SuggestedTemporaryPlayers = ""
-	globalBoard.suggestions.SuggestedPlayers = suggestedPlayers
-	globalBoard.suggestions.SuggestedCharacters = suggestedCharacters
-	globalBoard.suggestions.excalibur.Player = pl.ExcaliburPlayer
-	globalBoard.suggestions.excalibur.Suggester = globalBoard.PlayerNames[suggesterIn].Player
-	globalBoard.suggestions.OnlyGoodSuggested = false
+	log.Println("SuggestedPlayers:", suggestedPlayers, ",ExcaliburPlayer:", pl.ExcaliburPlayer, ",Suggester:", board.PlayerNames[suggesterIn].Player)
+	board.suggestions.SuggestedTemporaryPlayers = ""
+	board.suggestions.SuggestedPlayers = suggestedPlayers
+	board.suggestions.SuggestedCharacters = suggestedCharacters
+	board.suggestions.excalibur.Player = pl.ExcaliburPlayer
+	board.suggestions.excalibur.Suggester = board.PlayerNames[suggesterIn].Player
+	board.suggestions.OnlyGoodSuggested = false
 
 	allGood := true
 	for ch, val := range suggestedCharacters {
@@ -72,46 +60,45 @@ func HandleNewSuggest(pl Suggestion) {
 		allGood = false
 	}
 	if allGood {
-		globalBoard.suggestions.OnlyGoodSuggested = true
+		board.suggestions.OnlyGoodSuggested = true
 	}
 
-	globalBoard.State = SuggestionVoting
+	board.setState(SuggestionVoting)
 	suggestedPlayersString := strings.Join(suggestedPlayers[:], ",")
-	globalBoard.StateDescription = "Vote For New Suggestion: by: " + globalBoard.PlayerNames[suggesterIn].Player + "; Suggested Players: " + suggestedPlayersString
+	board.StateDescription = "Vote For New Suggestion: by: " + board.PlayerNames[suggesterIn].Player + "; Suggested Players: " + suggestedPlayersString
 	if pl.ExcaliburPlayer != "" {
-		globalBoard.StateDescription += "; Excalibur: " + pl.ExcaliburPlayer
+		board.StateDescription += "; Excalibur: " + pl.ExcaliburPlayer
 	}
-	globalBoard.votesForNextMission = make(map[string]bool)
-	globalBoard.suggestions.playersVotedYes = make([]string, 0)
-	globalBoard.suggestions.playersVotedNo = make([]string, 0)
+	board.votesForNextMission = make(map[string]bool)
+	board.suggestions.playersVotedYes = make([]string, 0)
+	board.suggestions.playersVotedNo = make([]string, 0)
 
 	/* Hammer */
-	if globalConfigPerNumOfPlayers[globalBoard.numOfPlayers].RetriesPerLevel[globalBoard.quests.current]-1 ==
-		globalBoard.suggestions.unsuccessfulRetries {
+	if board.rules.HammerApproves && board.isLastRetry() {
 
-		if HandleAcceptedSuggestion(globalBoard.numOfPlayers, &newEntry) {
-			return
+		if board.HandleAcceptedSuggestion(board.layout.NumOfQuests, &newEntry) {
+			return nil
 		}
 
-		allPlayers := make([]string, len(globalBoard.PlayerNames))
-		for _, player := range globalBoard.PlayerNames {
+		allPlayers := make([]string, 0, len(board.PlayerNames))
+		for _, player := range board.PlayerNames {
 			allPlayers = append(allPlayers, player.Player)
 		}
 
 		newEntry.PlayersVotedYes = allPlayers
-		newEntry.LadySuggester = globalBoard.ladyOfTheLake.currentSuggester //lady of the lake
-		globalBoard.suggestions.playersVotedYes = allPlayers
+		newEntry.LadySuggester = board.ladyOfTheLake.currentSuggester //lady of the lake
+		board.suggestions.playersVotedYes = allPlayers
 
-		globalBoard.suggestions.suggesterIndex = (globalBoard.suggestions.suggesterIndex+1) % len(globalBoard.PlayerNames)
+		board.passSuggestion()
 
 	}
-	globalBoard.archive = append(globalBoard.archive, newEntry)
-	globalMutex.Unlock()
-
+	board.archive = append(board.archive, newEntry)
+	board.mutex.Unlock()
+	return nil
 }
 
-func HandleTemporarySuggest(pl []string) {
-	globalMutex.Lock()
+func (board *BoardGame) HandleTemporarySuggest(pl []string) error {
+	board.mutex.Lock()
 	suggestedPlayersStr := ""
 
 	for i, v := range pl {
@@ -121,67 +108,71 @@ func HandleTemporarySuggest(pl []string) {
 		suggestedPlayersStr += v
 	}
 
-	globalBoard.suggestions.SuggestedTemporaryPlayers = suggestedPlayersStr
-	suggesterIn := globalBoard.suggestions.suggesterIndex % len(globalBoard.PlayerNames)
-	globalBoard.StateDescription = globalBoard.PlayerNames[suggesterIn].Player +
-		" is suggesting " + globalBoard.suggestions.SuggestedTemporaryPlayers + "..."
-	globalMutex.Unlock()
-
+	board.suggestions.SuggestedTemporaryPlayers = suggestedPlayersStr
+	suggesterIn := board.suggestions.suggesterIndex % len(board.PlayerNames)
+	board.StateDescription = board.PlayerNames[suggesterIn].Player +
+		" is suggesting " + board.suggestions.SuggestedTemporaryPlayers + "..."
+	board.mutex.Unlock()
+	return nil
 }
 
 
-func isCharacterExists(lockHeld bool, character string) (PlayerName, bool) {
+func (board *BoardGame) isCharacterExists(lockHeld bool, character string) (PlayerName, bool) {
 	if !lockHeld {
-		globalMutex.RLock()
+		board.mutex.RLock()
 	}
 
-	player, exists := globalBoard.CharacterToPlayer[character]
+	player, exists := board.CharacterToPlayer[character]
 
 	if !lockHeld {
-		globalMutex.RUnlock()
+		board.mutex.RUnlock()
 	}
 	return player, exists
 }
 
 
-func HandleSuggestionVote(vote VoteForSuggestion) {
+func (board *BoardGame) HandleSuggestionVote(vote VoteForSuggestion) error {
 	log.Println("suggestion -  ", vote.PlayerName, " voted ", vote.Vote)
 
-	globalMutex.Lock()
-	if globalBoard.votesForNextMission == nil {
-		globalBoard.votesForNextMission = make(map[string]bool)
+	board.mutex.Lock()
+	if board.votesForNextMission == nil {
+		board.votesForNextMission = make(map[string]bool)
 	}
 
-	if _, ok := globalBoard.votesForNextMission[vote.PlayerName]; ok {
-		globalMutex.Unlock()
-		return
+	if _, ok := board.votesForNextMission[vote.PlayerName]; ok {
+		board.mutex.Unlock()
+		return newCommandError(ErrAlreadyVoted, vote.PlayerName+" already voted for this suggestion")
+	}
+	if err := board.validateOpinionVote(vote.PlayerName); err != nil {
+		board.mutex.Unlock()
+		return err
 	}
 
-	globalBoard.votesForNextMission[vote.PlayerName] = vote.Vote
-	curEntry := globalBoard.archive[len(globalBoard.archive)-1]
+	board.votesForNextMission[vote.PlayerName] = vote.Vote
+	curEntry := board.archive[len(board.archive)-1]
 	if vote.Vote == true {
-		globalBoard.suggestions.playersVotedYes = append(globalBoard.suggestions.playersVotedYes, vote.PlayerName)
+		board.suggestions.playersVotedYes = append(board.suggestions.playersVotedYes, vote.PlayerName)
 		curEntry.PlayersVotedYes = append(curEntry.PlayersVotedYes, vote.PlayerName)
-		globalBoard.isSuggestionGood++ //inc good counter
+		board.isSuggestionGood++ //inc good counter
 	} else {
-		globalBoard.suggestions.playersVotedNo = append(globalBoard.suggestions.playersVotedNo, vote.PlayerName)
+		board.suggestions.playersVotedNo = append(board.suggestions.playersVotedNo, vote.PlayerName)
 		curEntry.PlayersVotedNo = append(curEntry.PlayersVotedNo, vote.PlayerName)
-		globalBoard.isSuggestionBad++ //inc bad counter
+		board.isSuggestionBad++ //inc bad counter
 	}
 
-	if len(globalBoard.votesForNextMission) == globalBoard.numOfConnectedPlayers { //last vote
-		log.Println("vote is over. num of players =", globalBoard.numOfConnectedPlayers)
+	if len(board.votesForNextMission) == board.numOfConnectedPlayers { //last vote
+		log.Println("vote is over. num of players =", board.numOfConnectedPlayers)
 
-		numOfQuests := globalConfigPerNumOfPlayers[globalBoard.numOfPlayers].NumOfQuests
-		if globalBoard.quests.current+1 == numOfQuests { //last quest in game
-			if gawainPlayer, ok := globalBoard.CharacterToPlayer["Gawain"]; ok {
-				for _, c := range globalBoard.suggestions.SuggestedPlayers {
+		numOfQuests := board.layout.NumOfQuests
+		if board.quests.current+1 == numOfQuests { //last quest in game
+			if gawainPlayer, ok := board.CharacterToPlayer["Gawain"]; ok {
+				for _, c := range board.suggestions.SuggestedPlayers {
 					if c == gawainPlayer.Player {
-						if gaVote, ok := globalBoard.votesForNextMission[gawainPlayer.Player]; ok {
+						if gaVote, ok := board.votesForNextMission[gawainPlayer.Player]; ok {
 							if gaVote {
-								globalBoard.isSuggestionGood++
+								board.isSuggestionGood++
 							} else {
-								globalBoard.isSuggestionBad++
+								board.isSuggestionBad++
 							}
 						}
 					}
@@ -190,44 +181,51 @@ func HandleSuggestionVote(vote VoteForSuggestion) {
 			}
 		}
 
-		if globalBoard.isSuggestionGood > globalBoard.isSuggestionBad {
+		if board.isSuggestionGood > board.isSuggestionBad {
 
-			if HandleAcceptedSuggestion(numOfQuests, &curEntry) {
-				return
+			if board.HandleAcceptedSuggestion(numOfQuests, &curEntry) {
+				return nil
 			}
+		} else if board.isLastRetry() {
+			/* No hammer: the last suggestion of the quest was rejected */
+			board.archive[len(board.archive)-1] = curEntry
+			log.Println(board.retriesFor(board.quests.current), "suggestions were rejected")
+			board.BadsWin()
+			board.mutex.Unlock()
+			return nil
 		} else {
-			globalBoard.State = WaitingForSuggestion
+			board.setState(WaitingForSuggestion)
 
-			suggesterIndex := globalBoard.suggestions.suggesterIndex
-			globalBoard.StateDescription = "Suggestion For Next Quest: " + globalBoard.PlayerNames[suggesterIndex].Player +
+			suggesterIndex := board.suggestions.suggesterIndex
+			board.StateDescription = "Suggestion For Next Quest: " + board.PlayerNames[suggesterIndex].Player +
 				" is choosing players..."
 
-			globalBoard.QuestStage += 0.1
-			globalBoard.QuestStage = float32(math.Round(float64(globalBoard.QuestStage*100)) / 100)
-			globalBoard.suggestions.unsuccessfulRetries++
+			board.QuestStage += 0.1
+			board.QuestStage = float32(math.Round(float64(board.QuestStage*100)) / 100)
+			board.suggestions.unsuccessfulRetries++
 		}
 
-		globalBoard.isSuggestionGood, globalBoard.isSuggestionBad = 0, 0
-		globalBoard.suggestions.suggesterIndex++
-		globalBoard.suggestions.suggesterIndex = globalBoard.suggestions.suggesterIndex % len(globalBoard.PlayerNames)
+		board.isSuggestionGood, board.isSuggestionBad = 0, 0
+		board.passSuggestion()
 	}
-	globalBoard.archive[len(globalBoard.archive)-1] = curEntry
+	board.archive[len(board.archive)-1] = curEntry
 
-	globalMutex.Unlock()
+	board.mutex.Unlock()
+	return nil
 }
 
-func HandleAcceptedSuggestion(numOfQuests int, curEntry* QuestArchiveItem) bool {
+func (board *BoardGame) HandleAcceptedSuggestion(numOfQuests int, curEntry* QuestArchiveItem) bool {
 	/*
 		Gawain's logic: If it's the last quest, the suggestion was accepted
 		and Gawain is included - he WINS the game!
 	*/
-	if gawainPlayer, exists := isCharacterExists(true, "Gawain"); exists {
-		if globalBoard.quests.current+1 == numOfQuests {
-			for _, c := range globalBoard.suggestions.SuggestedPlayers {
+	if gawainPlayer, exists := board.isCharacterExists(true, "Gawain"); exists {
+		if board.quests.current+1 == numOfQuests {
+			for _, c := range board.suggestions.SuggestedPlayers {
 				if c == gawainPlayer.Player {
-					globalBoard.State = VictoryForGawain
-					globalBoard.StateDescription = "VICTORY for Gawain"
-					globalMutex.Unlock()
+					board.setState(VictoryForGawain)
+					board.StateDescription = "VICTORY for Gawain"
+					board.mutex.Unlock()
 					return true
 				}
 			}
@@ -237,14 +235,14 @@ func HandleAcceptedSuggestion(numOfQuests int, curEntry* QuestArchiveItem) bool
 	isPellinoreInQuest := false
 	isBeastInQuest := false
 	log.Println("accepted quest")
-	for _, c := range globalBoard.suggestions.SuggestedPlayers {
-		if char, ok := globalBoard.PlayerToCharacter[PlayerName{c}]; ok {
+	for _, c := range board.suggestions.SuggestedPlayers {
+		if char, ok := board.PlayerToCharacter[PlayerName{c}]; ok {
 			if char == Pellinore {
 				log.Println("found Pellinore")
 				isPellinoreInQuest = true
 			}
 		}
-		if char, ok := globalBoard.PlayerToCharacter[PlayerName{c}]; ok {
+		if char, ok := board.PlayerToCharacter[PlayerName{c}]; ok {
 			if char == TheQuestingBeast {
 				log.Println("found TheQuestingBeast")
 				isBeastInQuest = true
@@ -252,27 +250,28 @@ func HandleAcceptedSuggestion(numOfQuests int, curEntry* QuestArchiveItem) bool
 		}
 	}
 	if isPellinoreInQuest && isBeastInQuest {
-		globalBoard.quests.Flags[BEAST_AND_PELLINORE_AT_SAME_QUEST] = true
+		board.quests.Flags[BEAST_AND_PELLINORE_AT_SAME_QUEST] = true
 	}
-	globalBoard.State = JorneyVoting
-	globalBoard.StateDescription = "The Quest was accepted. The Vote for Quest " + strconv.Itoa(globalBoard.quests.current+1) + " is starting now... "
+	board.plotTeamApproved()
+	board.setState(JorneyVoting)
+	board.StateDescription = "The Quest was accepted. The Vote for Quest " + strconv.Itoa(board.quests.current+1) + " is starting now... "
 	curEntry.IsSuggestionAccepted = true
-	globalBoard.suggestions.unsuccessfulRetries = 0
-	globalBoard.LastQuestStage = globalBoard.QuestStage
-	globalBoard.QuestStage += 0.01
-	globalBoard.QuestStage = float32(math.Ceil(float64(globalBoard.QuestStage)))
+	board.suggestions.unsuccessfulRetries = 0
+	board.LastQuestStage = board.QuestStage
+	board.QuestStage += 0.01
+	board.QuestStage = float32(math.Ceil(float64(board.QuestStage)))
 
-	if _, exists := isCharacterExists(true, Viviana); exists {
+	if _, exists := board.isCharacterExists(true, Viviana); exists {
 		/* Suggestion was accepted, viviana get a new secret about the suggester. */
-		UncoverSuggesterToViviana()
+		board.UncoverSuggesterToViviana()
 	}
 
-	if globalBoard.quests.Flags[HAS_BALAIN_AND_BALIN] {
-		balinPlayer := globalBoard.CharacterToPlayer[Balin]
-		balainPlayer := globalBoard.CharacterToPlayer[Balain]
+	if board.quests.Flags[HAS_BALAIN_AND_BALIN] {
+		balinPlayer := board.CharacterToPlayer[Balin]
+		balainPlayer := board.CharacterToPlayer[Balain]
 		balinIsSuggestion := false
 		balainIsSuggestion := false
-		for _, c := range globalBoard.suggestions.SuggestedPlayers {
+		for _, c := range board.suggestions.SuggestedPlayers {
 			if c == balinPlayer.Player {
 				balinIsSuggestion = true
 			}
@@ -281,81 +280,73 @@ func HandleAcceptedSuggestion(numOfQuests int, curEntry* QuestArchiveItem) bool
 			}
 		}
 		if balainIsSuggestion && balinIsSuggestion {
-			globalBoard.CharacterToPlayer[Balain] = balinPlayer
-			globalBoard.CharacterToPlayer[Balin] = balainPlayer
-			globalBoard.PlayerToCharacter[balinPlayer] = Balain
-			globalBoard.PlayerToCharacter[balainPlayer] = Balin
-			// If Lancelot-Bad was chosen to be the assassin, we need to update that.
-			assasinPlayer, _ := globalBoard.CharacterToPlayer[Assassin]
-			if assasinPlayer == balinPlayer {
-				globalBoard.CharacterToPlayer[Assassin] = balinPlayer
-			}
+			board.changeIdentities(IdentityBalin, board.quests.current+1,
+				identitySwap{balinPlayer, Balain}, identitySwap{balainPlayer, Balin})
 		}
 	}
 
-	numOfUnsuccesfulRetries := globalConfigPerNumOfPlayers[globalBoard.numOfPlayers].RetriesPerLevel[globalBoard.quests.current]
-	suggesterVetoIn := (globalBoard.suggestions.suggesterIndex + 1 + numOfUnsuccesfulRetries) % len(globalBoard.PlayerNames)
-	globalBoard.suggestions.PlayerWithVeto = globalBoard.PlayerNames[suggesterVetoIn].Player
+	board.setVetoPlayer(board.suggestions.suggesterIndex+1, board.quests.current+1)
 	return false
 }
 
-func UncoverSuggesterToViviana() {
-	suggesterIndex := globalBoard.suggestions.suggesterIndex % len(globalBoard.PlayerNames)
-	suggesterPlayerName := globalBoard.PlayerNames[suggesterIndex]
-	suggesterCharacter := globalBoard.PlayerToCharacter[suggesterPlayerName]
-	strayPlayer, strayExists := isCharacterExists(true, Stray)
+func (board *BoardGame) UncoverSuggesterToViviana() {
+	suggesterIndex := board.suggestions.suggesterIndex % len(board.PlayerNames)
+	suggesterPlayerName := board.PlayerNames[suggesterIndex]
+	suggesterCharacter := board.PlayerToCharacter[suggesterPlayerName]
+	strayPlayer, strayExists := board.isCharacterExists(true, Stray)
 	if strayExists && strayPlayer == suggesterPlayerName {
 		suggesterCharacter = Stray
 	}
-	if SirKay == suggesterCharacter {
-		UncoverAsBadCharacter(suggesterPlayerName)
-	} else if Mordred == suggesterCharacter {
-		UncoverAsGoodCharacter(suggesterPlayerName)
-	} else if Lot == suggesterCharacter {
-		UncoverAsBadCharacter(suggesterPlayerName)
-		UncoverCharacter(suggesterPlayerName, Lot)
-	} else if "Gawain" == suggesterCharacter {
-		UncoverAsBadCharacter(suggesterPlayerName)
-		UncoverCharacter(suggesterPlayerName, "Gawain")
-	} else if "Ginerva" == suggesterCharacter {
-		UncoverAsBadCharacter(suggesterPlayerName)
-	} else if Stray == suggesterCharacter {
-		UncoverCharacter(suggesterPlayerName, Stray)
-	} else if Oberon == suggesterCharacter {
-		UncoverCharacter(suggesterPlayerName, Oberon)
-	} else if _, isSuggesterBadCharacter := badCharacters[suggesterCharacter]; isSuggesterBadCharacter {
-		log.Println("suggester is bad")
-		UncoverAsBadCharacter(suggesterPlayerName)
+	if rules := characterRules(suggesterCharacter); rules != nil {
+		rules.ShownToViviana(board, suggesterPlayerName, suggesterCharacter)
 	} else {
-		log.Println("suggester is good")
-		UncoverAsGoodCharacter(suggesterPlayerName)
+		vivianaSeesGood(board, suggesterPlayerName, suggesterCharacter)
 	}
 }
 
-func UncoverCharacter(suggesterPlayerName PlayerName, character string) {
-	if nil == globalBoard.playersWithCharacters {
-		globalBoard.playersWithCharacters = make(map[string]string)
+func vivianaSeesGood(board *BoardGame, suggester PlayerName, character string) {
+	log.Println("suggester is good")
+	board.UncoverAsGoodCharacter(suggester)
+}
+
+func vivianaSeesBad(board *BoardGame, suggester PlayerName, character string) {
+	log.Println("suggester is bad")
+	board.UncoverAsBadCharacter(suggester)
+}
+
+func vivianaSeesRole(board *BoardGame, suggester PlayerName, character string) {
+	board.UncoverCharacter(suggester, character)
+}
+
+func vivianaSeesBadAndRole(board *BoardGame, suggester PlayerName, character string) {
+	board.UncoverAsBadCharacter(suggester)
+	board.UncoverCharacter(suggester, character)
+}
+
+func (board *BoardGame) UncoverCharacter(suggesterPlayerName PlayerName, character string) {
+	if nil == board.playersWithCharacters {
+		board.playersWithCharacters = make(map[string]string)
 	}
-	globalBoard.playersWithCharacters[suggesterPlayerName.Player] = character
-	vivianaPlayer, _ := globalBoard.CharacterToPlayer[Viviana]
-	globalBoard.SecretsMap[vivianaPlayer.Player].PlayersWithUncoveredCharacters[suggesterPlayerName.Player] = character
+	board.playersWithCharacters[suggesterPlayerName.Player] = character
+	vivianaPlayer, _ := board.CharacterToPlayer[Viviana]
+	board.SecretsMap[vivianaPlayer.Player].PlayersWithUncoveredCharacters[suggesterPlayerName.Player] = character
 }
 
-func UncoverAsBadCharacter(suggesterPlayerName PlayerName) {
-	vivianaPlayer, _ := globalBoard.CharacterToPlayer[Viviana]
+func (board *BoardGame) UncoverAsBadCharacter(suggesterPlayerName PlayerName) {
+	vivianaPlayer, _ := board.CharacterToPlayer[Viviana]
 
-	log.Println("before:", globalBoard.SecretsMap[vivianaPlayer.Player])
-	globalBoard.SecretsMap[vivianaPlayer.Player].PlayersWithBadCharacter = append(globalBoard.SecretsMap[vivianaPlayer.Player].PlayersWithBadCharacter, suggesterPlayerName.Player)
-	log.Println("after:", globalBoard.SecretsMap[vivianaPlayer.Player])
-	globalBoard.PlayersWithBadCharacter = append(globalBoard.PlayersWithBadCharacter,
+	log.Println("before:", board.SecretsMap[vivianaPlayer.Player])
+	board.SecretsMap[vivianaPlayer.Player].PlayersWithBadCharacter = append(board.SecretsMap[vivianaPlayer.Player].PlayersWithBadCharacter, suggesterPlayerName.Player)
+	log.Println("after:", board.SecretsMap[vivianaPlayer.Player])
+	board.PlayersWithBadCharacter = append(board.PlayersWithBadCharacter,
 		suggesterPlayerName.Player)
 }
 
-func UncoverAsGoodCharacter(suggesterPlayerName PlayerName) {
-	vivianaPlayer, _ := globalBoard.CharacterToPlayer[Viviana]
+func (board *BoardGame) UncoverAsGoodCharacter(suggesterPlayerName PlayerName) {
+	vivianaPlayer, _ := board.CharacterToPlayer[Viviana]
 
-	globalBoard.SecretsMap[vivianaPlayer.Player].PlayersWithGoodCharacter = append(globalBoard.SecretsMap[vivianaPlayer.Player].PlayersWithGoodCharacter, suggesterPlayerName.Player)
+	board.SecretsMap[vivianaPlayer.Player].PlayersWithGoodCharacter = append(board.SecretsMap[vivianaPlayer.Player].PlayersWithGoodCharacter, suggesterPlayerName.Player)
 
-	globalBoard.playersWithGoodCharacter = append(globalBoard.playersWithGoodCharacter,
+	board.playersWithGoodCharacter = append(board.playersWithGoodCharacter,
 		suggesterPlayerName.Player)
 }
\ No newline at end of file
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+const TheLovers = "The-Lovers"
+
+const (
+	MurderSucceeded = "success"
+	MurderFailed    = "failure"
+	MurderConverted = "converted" // the named player changed sides instead of dying
+)
+
+// targetingRoles are the roles the Assassin may go after in the Targeting module, in the order they are offered.
+var targetingRoles = []string{Merlin, Percival, TheLovers, Cleric}
+
+// targetingMurders is the Assassin's murder in the Targeting module: one of the targeting roles in the game,
+// declared before the players are chosen.
+func targetingMurders(board *BoardGame) []Murder {
+	targetCharacters := make([]string, 0)
+	targetSlice := make([]string, 0)
+	for _, role := range targetingRoles {
+		if role == TheLovers {
+			tristan, isTristanExists := board.CharacterToPlayer[Tristan]
+			iseult, isIseultExists := board.CharacterToPlayer[Iseult]
+			if isTristanExists && isIseultExists {
+				targetSlice = append(targetSlice, tristan.Player, iseult.Player)
+				targetCharacters = append(targetCharacters, TheLovers)
+			}
+			continue
+		}
+		if targetPlayerName, ok := board.CharacterToPlayer[role]; ok {
+			targetSlice = append(targetSlice, targetPlayerName.Player)
+			targetCharacters = append(targetCharacters, role)
+		}
+	}
+	if len(targetCharacters) == 0 {
+		return nil
+	}
+	assassin := board.CharacterToPlayer[Assassin]
+	return []Murder{{target: targetSlice, TargetCharacters: targetCharacters, By: assassin.Player, ByCharacter: Assassin, StateAfterSuccess: VictoryForBad}}
+}
+
+// DeclareTargetHandler records the role the Assassin goes after, before the murder.
+func (board *BoardGame) DeclareTargetHandler(target string) error {
+	board.mutex.Lock()
+	defer board.mutex.Unlock()
+
+	if !board.targeting || len(board.PendingMurders) == 0 || board.PendingMurders[0].ByCharacter != Assassin {
+		return newCommandError(ErrWrongState, "only the assassin declares a target, in the targeting module")
+	}
+	curMurder := &board.PendingMurders[0]
+	if curMurder.Declared != "" {
+		return newCommandError(ErrInvalidTarget, "the assassin already goes after "+curMurder.Declared)
+	}
+	if SliceIndex(len(curMurder.TargetCharacters), func(i int) bool { return curMurder.TargetCharacters[i] == target }) < 0 {
+		return newCommandError(ErrInvalidTarget, target+" is not one of: "+strings.Join(curMurder.TargetCharacters, ","))
+	}
+	log.Println("assassin declared target:", target)
+	curMurder.Declared = target
+	board.StateDescription = "Murder: " + Assassin + " is going after: " + target
+	return nil
+}
+
+// recordMurder keeps the murder, the chosen players and its outcome in the archive entry of the last suggestion.
+func (board *BoardGame) recordMurder(murder Murder, chosenPlayers []string, outcome string) {
+	murder.Chosen = chosenPlayers
+	murder.Outcome = outcome
+	if len(board.archive) == 0 {
+		return
+	}
+	last := &board.archive[len(board.archive)-1]
+	last.Murders = append(last.Murders, murder)
+}
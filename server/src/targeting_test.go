@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_Targeting(t *testing.T) {
+	t.Run("Offers the targeting roles in the game", should_offer_targeting_roles)
+	t.Run("Rejects a murder before the declaration", should_reject_murder_before_declaration)
+	t.Run("Rejects a target outside the game", should_reject_undeclarable_target)
+	t.Run("Judges the murder by the declared target", should_judge_murder_by_declaration)
+	t.Run("Records the murder in the archive", should_record_murder_in_archive)
+}
+
+// newTargetingRoom has the goods win the quests, so the Assassin declares a target.
+func newTargetingRoom(t *testing.T) *BoardGame {
+	board := startTestGame(t, GameConfiguration{Targeting: true}, Merlin, Assassin, Percival, Morgana,
+		LoyalServentOfArthur)
+	winQuests(t, board, playersOf(board, Merlin, Percival, LoyalServentOfArthur)...)
+	if board.State != MurdersAfterGoodVictory {
+		t.Fatal("The goods should win the quests, got", board.State)
+	}
+	return board
+}
+
+func should_offer_targeting_roles(t *testing.T) {
+	//Arrange
+	board := newTargetingRoom(t)
+
+	//Act
+	murder := board.PendingMurders[0]
+
+	//Assert
+	if !sameStringSlice(murder.TargetCharacters, []string{Merlin, Percival}) || murder.By != playerOf(board, Assassin) {
+		t.Error("The assassin should go after Merlin or Percival, got", murder)
+	}
+}
+
+func should_reject_murder_before_declaration(t *testing.T) {
+	//Arrange
+	board := newTargetingRoom(t)
+
+	//Act
+	err := board.HandleMurder(MurderMessageInternal{CharacterKill: Merlin, Rest: []PlayerNameMurder{{Player: playerOf(board, Merlin), Ch: true}}})
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidTarget {
+		t.Error("Expected invalid_target, got", err)
+	}
+	if board.State != MurdersAfterGoodVictory || len(board.PendingMurders) != 1 {
+		t.Error("The murder should still be pending, got", board.State, board.PendingMurders)
+	}
+}
+
+func should_reject_undeclarable_target(t *testing.T) {
+	//Arrange
+	board := newTargetingRoom(t)
+
+	//Act
+	err := board.DeclareTargetHandler(Cleric)
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidTarget {
+		t.Error("Expected invalid_target, got", err)
+	}
+	if board.PendingMurders[0].Declared != "" {
+		t.Error("Nothing should be declared, got", board.PendingMurders[0].Declared)
+	}
+}
+
+func should_judge_murder_by_declaration(t *testing.T) {
+	//Arrange
+	board := newTargetingRoom(t)
+	if err := board.DeclareTargetHandler(Percival); err != nil {
+		t.Fatal("Percival should be declarable, got", err)
+	}
+
+	//Act
+	board.HandleMurder(MurderMessageInternal{CharacterKill: Merlin, Rest: []PlayerNameMurder{{Player: playerOf(board, Merlin), Ch: true}}})
+
+	//Assert
+	if board.State != VictoryForGood {
+		t.Error("Killing Merlin after declaring Percival should miss, got", board.State)
+	}
+}
+
+func should_record_murder_in_archive(t *testing.T) {
+	//Arrange
+	board := newTargetingRoom(t)
+	board.DeclareTargetHandler(Percival)
+	percival := playerOf(board, Percival)
+
+	//Act
+	board.HandleMurder(MurderMessageInternal{Rest: []PlayerNameMurder{{Player: percival, Ch: true}}})
+
+	//Assert
+	archive := board.GetGameState("alice").Archive
+	murders := archive[len(archive)-1].Murders
+	if board.State != VictoryForBad {
+		t.Error("Killing the declared Percival should win for the bads, got", board.State)
+	}
+	if len(murders) != 1 || murders[0].Declared != Percival || murders[0].Outcome != MurderSucceeded ||
+		!sameStringSlice(murders[0].Chosen, []string{percival}) {
+		t.Error("The archive should record the declared target and the outcome, got", murders)
+	}
+}
@@ -1,5 +1,7 @@
 package main
 
+import "sort"
+
 func sameStringSlice(x, y []string) bool {
 	if len(x) != len(y) {
 		return false
@@ -44,3 +46,13 @@ func removeElementFromStringSlice(slice []string, s int) []string {
 }
 
 
+
+// sortedKeys returns the keys of a set in order, so that random picks over them can be replayed from a seed.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+package main
+
+import "strconv"
+
+// The validations below run with the board lock held, after checkCommand.
+
+// isActivePlayer tells whether player is seated and plays quests (Ector sits out).
+func (board *BoardGame) isActivePlayer(player string) bool {
+	for _, p := range board.PlayerNames {
+		if p.Player == player {
+			return board.PlayerToCharacter[p] != Ector
+		}
+	}
+	return false
+}
+
+func (board *BoardGame) isSeated(player string) bool {
+	for _, p := range board.PlayerNames {
+		if p.Player == player {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSuggestion checks the team size of the current quest and that every member is an active player.
+func (board *BoardGame) validateSuggestion(pl Suggestion) error {
+	if err := board.validatePlotTurn(); err != nil {
+		return err
+	}
+	required := board.quests.results[board.quests.current+1].NumOfPlayers
+	if len(pl.Players) != required {
+		return newCommandError(ErrInvalidTeam, "quest "+strconv.Itoa(board.quests.current+1)+" needs "+
+			strconv.Itoa(required)+" players, got "+strconv.Itoa(len(pl.Players)))
+	}
+	members := make(map[string]bool)
+	for _, player := range pl.Players {
+		if !board.isActivePlayer(player) {
+			return newCommandError(ErrInvalidTeam, player+" is not an active player")
+		}
+		if members[player] {
+			return newCommandError(ErrInvalidTeam, player+" is suggested twice")
+		}
+		members[player] = true
+	}
+	if err := board.validateHouseRules(pl); err != nil {
+		return err
+	}
+	if pl.ExcaliburPlayer != "" {
+		if !board.quests.Flags[EXCALIBUR] {
+			return newCommandError(ErrInvalidTeam, "excalibur is not in this game")
+		}
+		if !members[pl.ExcaliburPlayer] {
+			return newCommandError(ErrInvalidTeam, "excalibur must go to a member of the team")
+		}
+	}
+	return nil
+}
+
+// validateJourneyVote checks the vote is one getOptionalVotesAccordingToQuestMembers offers.
+func (board *BoardGame) validateJourneyVote(vote VoteForJourney) error {
+	character := board.PlayerToCharacter[PlayerName{vote.PlayerName}]
+	options := board.getOptionalVotesAccordingToQuestMembers(character, board.suggestions.SuggestedCharacters,
+		board.quests.Flags, board.quests.current, board.numOfPlayers)
+	for _, option := range options {
+		if option == getVoteStr(vote.Vote) {
+			return nil
+		}
+	}
+	return newCommandError(ErrInvalidVote, vote.PlayerName+" cannot vote "+getVoteStr(vote.Vote))
+}
+
+// validateLadyTarget checks the Lady goes to a seated player that never held it.
+func (board *BoardGame) validateLadyTarget(target string) error {
+	if !board.isSeated(target) {
+		return newCommandError(ErrInvalidTarget, target+" is not seated")
+	}
+	if board.ladyOfTheLake.holders[target] {
+		return newCommandError(ErrInvalidTarget, target+" already held the Lady")
+	}
+	return nil
+}
+
+// validateLadyAnswer checks the chosen player answers the Lady with one of the loyalties getOptionalLoyalty offers.
+// The client answers anything but GOOD with 0, so an offered NEUTRAL is answered that way.
+func (board *BoardGame) validateLadyAnswer(loyalty int) error {
+	chosen := board.ladyOfTheLake.currentChosenPlayer
+	for _, option := range board.getOptionalLoyalty(chosen) {
+		if option == getLoyaltyStr(loyalty) || (option == NEUTRAL && loyalty != 1) {
+			return nil
+		}
+	}
+	return newCommandError(ErrInvalidVote, chosen+" cannot answer "+getLoyaltyStr(loyalty))
+}
@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+func Test_Validation(t *testing.T) {
+	t.Run("Rejects a team of the wrong size", should_reject_team_of_wrong_size)
+	t.Run("Rejects a team with a player that is not seated", should_reject_team_with_unseated_player)
+	t.Run("Rejects a quest vote from a player that is not on the quest", should_reject_quest_vote_from_non_member)
+	t.Run("Rejects a vote value the character cannot play", should_reject_vote_value_not_offered)
+}
+
+func should_reject_team_of_wrong_size(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+
+	//Act
+	err := board.HandleNewSuggest(Suggestion{Players: otherPlayers(board)[:3]})
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidTeam {
+		t.Error("Expected invalid_team, got", err)
+	}
+	if board.State != WaitingForSuggestion {
+		t.Error("State should not change, got", board.State)
+	}
+}
+
+func should_reject_team_with_unseated_player(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+
+	//Act
+	err := board.HandleNewSuggest(Suggestion{Players: []string{board.leader(), "mallory"}})
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidTeam {
+		t.Error("Expected invalid_team, got", err)
+	}
+}
+
+func should_reject_quest_vote_from_non_member(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+	others := otherPlayers(board)
+	voteTeam(t, board, others[:2])
+
+	//Act
+	err := board.checkCommand("vote_for_journey", others[2])
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrNotOnQuest {
+		t.Error("Expected not_on_quest, got", err)
+	}
+}
+
+func should_reject_vote_value_not_offered(t *testing.T) {
+	//Arrange
+	board := startTestGame(t, GameConfiguration{}, classicRoles...)
+	merlin := playerOf(board, Merlin)
+	voteTeam(t, board, []string{merlin, playerOf(board, Assassin)})
+
+	//Act
+	err := board.HandleJourneyVote(VoteForJourney{PlayerName: merlin, Vote: VoteFail})
+
+	//Assert
+	if cerr, ok := err.(*CommandError); !ok || cerr.Code != ErrInvalidVote {
+		t.Error("Expected invalid_vote, got", err)
+	}
+	if len(board.quests.playerVotedForCurrent) != 0 {
+		t.Error("The vote should not be counted, got", board.quests.playerVotedForCurrent)
+	}
+}
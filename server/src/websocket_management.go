@@ -1,10 +1,11 @@
 package main
 
 import (
-	"bytes"
 	"encoding/json"
 	"github.com/gorilla/websocket"
 	"log"
+	"sync/atomic"
+	"time"
 )
 
 
@@ -19,6 +20,7 @@ type Message struct {
 	Sender    string `json:"sender,omitempty"`
 	Recipient string `json:"recipient,omitempty"`
 	Content   string `json:"content,omitempty"`
+	Seq       int64  `json:"seq,omitempty"`
 }
 
 type PlayerGone struct {
@@ -34,312 +36,279 @@ func (manager *ClientManager) start() {
 	//	runtime.Stack(buf, true)
 		//log.Println("%s", buf)
 	}()
+	idleTicker := time.NewTicker(idleCheckInterval)
+	defer idleTicker.Stop()
 	for {
 		log.Println("inside MANAGER loop")
 		select {
+		case <-idleTicker.C:
+			manager.checkIdleClients()
 		case conn := <-manager.register:
 			log.Println("register new connection")
-			if _, ok := manager.clients[conn]; !ok {
-				globalMutex.Lock()
-				found := false
-				for _, v := range globalBoard.PlayerNames {
-					if v.Player == conn.id {
-						found = true
-					}
-				}
-				if !found && (globalBoard.State == NotStarted || (globalBoard.State >= VictoryForGood && globalBoard.State <= VictoryForGawain)) {
-					log.Println("Adding", conn.id, " to player names list")
-					globalBoard.PlayerNames = append(globalBoard.PlayerNames, PlayerName{conn.id})
-				}
-
-				globalMutex.Unlock()
-				manager.clients[conn] = true
-				jsonMessage, _ := json.Marshal(&Message{Content: "/A new socket has connected."})
-				manager.send(jsonMessage, conn)
-			}
+			manager.registerClient(conn)
 		case conn := <-manager.unregister:
 			log.Println("unregister connection")
-			if _, ok := manager.clients[conn]; ok {
-				globalMutex.Lock()
-				//playerName, ok := globalBoard.clientIdToPlayerName[conn.id]
-				if ok {
-					log.Println("unregister ", conn.id)
-					if globalBoard.State == NotStarted || (globalBoard.State >= VictoryForGood && globalBoard.State <= VictoryForGawain) {
-						index := SliceIndex(len(globalBoard.PlayerNames), func(i int) bool { return globalBoard.PlayerNames[i] == PlayerName{conn.id} })
-						if index >= 0 {
-							globalBoard.PlayerNames = removePlayer(globalBoard.PlayerNames, index)
-							log.Println(conn.id, " was removed for player names list: ", globalBoard.PlayerNames)
-						}
-					}
+			manager.disconnect(conn)
+		case conn := <-manager.leave:
+			log.Println("client leaves room")
+			if manager.removeClient(conn) {
+				manager.notifySeatDisconnected(conn)
+			}
+		case message := <-manager.broadcast:
+			manager.handleBroadcast(message)
+		}
+	}
+}
 
-					delete(globalBoard.clientIdToPlayerName, conn.id)
-				}
+// registerClient seats a new socket. A seated player that comes back during the game, after dropping or
+// from a new socket that replaced a live one, resumes the seat.
+func (manager *ClientManager) registerClient(conn *Client) {
+	if _, ok := manager.clients[conn]; ok {
+		return
+	}
+	replaced := manager.replaceStaleClients(conn)
+	manager.board.mutex.Lock()
+	found := false
+	for _, v := range manager.board.PlayerNames {
+		if v.Player == conn.id {
+			found = true
+		}
+	}
+	if !found && manager.board.isSeatingOpen() {
+		log.Println("Adding", conn.id, " to player names list")
+		manager.board.PlayerNames = append(manager.board.PlayerNames, PlayerName{conn.id})
+	}
+	_, hasMissed := manager.missed[conn.id]
+	isResuming := found && (hasMissed || (replaced && !manager.board.isSeatingOpen()))
+	if isResuming {
+		manager.board.connections[conn.id] = SeatConnection{Status: SeatReconnected, LastSeen: conn.LastSeen()}
+	} else {
+		manager.board.connections[conn.id] = SeatConnection{Status: SeatConnected, LastSeen: conn.LastSeen()}
+	}
 
-				ls := ListOfPlayersResponse{Total: len(globalBoard.PlayerNames), Players: globalBoard.PlayerNames}
-				playersMsg, _ := json.Marshal(&PlayerGone{Type: "bla", Players: ls})
-				globalMutex.Unlock()
+	manager.board.mutex.Unlock()
+	manager.clients[conn] = true
+	jsonMessage, _ := json.Marshal(&Message{Content: "/A new socket has connected."})
+	manager.send(jsonMessage, conn)
+	if isResuming {
+		manager.resume(conn)
+		jsonMessage, _ = json.Marshal(&Message{Sender: conn.id, Content: "board"})
+		manager.handleBroadcast(jsonMessage)
+	}
+}
 
-				log.Println("before 84")
-				manager.send(playersMsg, conn)
+func (manager *ClientManager) handleBroadcast(message []byte) {
+	log.Println("send broadcast message")
+	manager.releaseGoneSeats()
+	var msg Message
+	json.Unmarshal(message, &msg)
+	manager.seq++
+	msg.Seq = manager.seq
+	if msg.Content != "board" {
+		message, _ = json.Marshal(&msg)
+	}
+	for conn := range manager.clients {
+		log.Println("conn:" + conn.id)
+		if msg.Content == "board" {
+			if !msg.isFor(conn.id) {
+				continue
+			}
+			if conn.patch.isEnabled() {
+				message = manager.patchMessage(&msg, conn)
+			} else {
+				message = manager.boardMessage(&msg, conn.id)
+			}
+		}
 
-				log.Println("before close(conn.send)")
-				close(conn.send)
-				log.Println("before delete(manager.clients, conn)")
-				delete(manager.clients, conn)
-				log.Println("before json.Marshal(&Message{Content")
-				jsonMessage, _ := json.Marshal(&Message{Content: "/A socket has disconnected."})
-				log.Println("before manager.send")
-				manager.send(jsonMessage, conn)
+		manager.enqueue(conn, message, msg.Content == "board")
+	}
+	for id := range manager.missed {
+		if msg.Content == "board" {
+			if !msg.isFor(id) {
+				continue
 			}
-		case message := <-manager.broadcast:
-			log.Println("send broadcast message")
-			var msg Message
-			json.Unmarshal(message, &msg)
-			for conn := range manager.clients {
-				log.Println("conn:" + conn.id)
-				if msg.Content == "board" {
-					if msg.Recipient != "" && msg.Recipient[0] != '^' && msg.Recipient != conn.id {
-						continue
-					}
-					if msg.Recipient != "" && msg.Recipient[0] == '^' && msg.Recipient[1:] == conn.id {
-						continue
-					}
-					gm := GetGameState(conn.id)
-					jsonMessage, _ := json.Marshal(&gm)
-					//log.Println(string(jsonMessage))
+			message = manager.boardMessage(&msg, id)
+		}
+		manager.keepMissed(id, msg.Seq, message)
+	}
+	log.Println("after iteration over conns")
+}
 
-					log.Println("Going to send the following state to ", conn.id)
-					var prettyJSON bytes.Buffer
-					json.Indent(&prettyJSON, jsonMessage, "", "\t")
-					//log.Println("Game State:", string(prettyJSON.Bytes()))
+func (manager *ClientManager) boardMessage(msg *Message, clientId string) []byte {
+	gm := manager.board.GetGameState(clientId)
+	jsonMessage, _ := json.Marshal(&gm)
+	//log.Println(string(jsonMessage))
 
-					message, _ = json.Marshal(&Message{Sender: msg.Sender, Content: string(jsonMessage)})
-				}
+	log.Println("Going to send the following state to ", clientId)
 
-				select {
-				case conn.send <- message:
-				default:
-					close(conn.send)
-					delete(manager.clients, conn)
-				}
-			}
-			log.Println("after iteration over conns")
+	message, _ := json.Marshal(&Message{Sender: msg.Sender, Seq: msg.Seq, Content: string(jsonMessage)})
+	return message
+}
+
+// isFor checks the recipient filter of a board message: "" is everyone, "x" only x and "^x" everyone but x.
+func (msg *Message) isFor(clientId string) bool {
+	if msg.Recipient != "" && msg.Recipient[0] != '^' && msg.Recipient != clientId {
+		return false
+	}
+	if msg.Recipient != "" && msg.Recipient[0] == '^' && msg.Recipient[1:] == clientId {
+		return false
+	}
+	return true
+}
+
+// disconnect removes a dead connection and closes its send channel, which stops the write thread.
+func (manager *ClientManager) disconnect(conn *Client) {
+	if manager.removeClient(conn) {
+		log.Println("before conn.send.close()")
+		conn.send.close()
+		log.Println("before json.Marshal(&Message{Content")
+		jsonMessage, _ := json.Marshal(&Message{Content: "/A socket has disconnected."})
+		log.Println("before manager.send")
+		manager.send(jsonMessage, conn)
+		manager.notifySeatDisconnected(conn)
+	}
+}
+
+// removeClient drops the connection from the room. If no game is running its seat is freed,
+// otherwise the seat is kept for the player to resume.
+func (manager *ClientManager) removeClient(conn *Client) bool {
+	if _, ok := manager.clients[conn]; !ok {
+		return false
+	}
+	manager.board.mutex.Lock()
+	log.Println("unregister ", conn.id)
+	index := SliceIndex(len(manager.board.PlayerNames), func(i int) bool { return manager.board.PlayerNames[i] == PlayerName{conn.id} })
+	if manager.board.isSeatingOpen() {
+		if index >= 0 {
+			manager.board.PlayerNames = removePlayer(manager.board.PlayerNames, index)
+			log.Println(conn.id, " was removed for player names list: ", manager.board.PlayerNames)
+		}
+		delete(manager.board.connections, conn.id)
+	} else if index >= 0 {
+		log.Println(conn.id, " disconnected during the game. keeping the seat")
+		manager.board.connections[conn.id] = SeatConnection{Status: SeatDisconnected, LastSeen: conn.LastSeen()}
+		if _, ok := manager.missed[conn.id]; !ok {
+			manager.missed[conn.id] = make([]sequencedMessage, 0)
 		}
 	}
+	delete(manager.board.clientIdToPlayerName, conn.id)
+
+	ls := ListOfPlayersResponse{Total: len(manager.board.PlayerNames), Players: manager.board.PlayerNames}
+	playersMsg, _ := json.Marshal(&PlayerGone{Type: "bla", Players: ls})
+	manager.board.mutex.Unlock()
+
+	log.Println("before 84")
+	manager.send(playersMsg, conn)
+
+	log.Println("before delete(manager.clients, conn)")
+	delete(manager.clients, conn)
+	return true
 }
 
 func (c *Client) write() {
+	ticker := time.NewTicker(pingInterval)
 	defer func() {
 		log.Println("client:", c.id, " write error. terminate thread")
+		ticker.Stop()
 		c.socket.Close()
 	}()
 
 	for {
 		select {
-		case message, ok := <-c.send:
-			if !ok {
+		case <-c.send.ready:
+			for {
+				message, ok := c.send.pop()
+				if !ok {
+					break
+				}
+				c.socket.SetWriteDeadline(time.Now().Add(writeWait))
+				if err := c.socket.WriteMessage(websocket.TextMessage, message); err != nil {
+					log.Println("client:", c.id, " write failed:", err)
+					return
+				}
+			}
+			if c.send.isClosed() {
+				c.socket.SetWriteDeadline(time.Now().Add(writeWait))
 				c.socket.WriteMessage(websocket.CloseMessage, []byte{})
+				log.Println("client:", c.id, " dropped", atomic.LoadInt64(&c.send.dropped), "and coalesced", atomic.LoadInt64(&c.send.coalesced), "messages")
+				return
+			}
+		case <-ticker.C:
+			c.socket.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.socket.WriteMessage(websocket.PingMessage, nil); err != nil {
+				log.Println("client:", c.id, " ping failed:", err)
 				return
 			}
-
-			c.socket.WriteMessage(websocket.TextMessage, message)
 		}
 	}
 }
 
 func (c *Client) read() {
 	defer func() {
-		//globalBoard.manager.unregister <- c
 		log.Println("client ", c.id, " read end (probably socket closed)")
 		//c.socket.Close()
 	}()
 	log.Println("client read start")
+	c.socket.SetReadDeadline(time.Now().Add(pongWait))
+	c.socket.SetPongHandler(func(string) error {
+		c.touch()
+		c.socket.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
 	for {
 		_, message, err := c.socket.ReadMessage()
-		notifyAll := false
 		if err != nil {
-			log.Println("socket read failure")
+			log.Println("socket read failure:", err)
 
-			globalMutex.Lock()
-			if (globalBoard.State == 0 || (globalBoard.State >= VictoryForGood && globalBoard.State <= VictoryForGawain)) && len(globalBoard.PlayerNames) > 0 {
-				log.Println("no game yet so we can remove player from player list: ", globalBoard.PlayerNames)
-				index := SliceIndex(len(globalBoard.PlayerNames), func(i int) bool { return globalBoard.PlayerNames[i] == PlayerName{c.id} })
-				if index > -1 {
-					globalBoard.PlayerNames = removePlayer(globalBoard.PlayerNames, index)
-				}
-				delete(globalBoard.clientIdToPlayerName, c.id)
+			board := c.room
+			if board == nil {
+				c.send.close()
+				c.socket.Close()
+				break
 			}
-			globalMutex.Unlock()
-
-			globalBoard.manager.unregister <- c
+			board.manager.unregister <- c
 			c.socket.Close()
-			notifyAll = true
 			break
 		}
-		dd := make(map[string]interface{})
-		//log.Println(string(message))
-		json.Unmarshal(message, &dd)
-
-		var isOnlyForAllExceptSender bool
-		isOnlyForSender := false
-		//log.Println(dd["type"])
-		tp := dd["type"]
-		isGameCommand := false
-		recipient := ""
-		log.Println("successfully read message. client:", c.id, ". type: ", tp)
-		if tp == "add_player" {
-
-			isGameCommand = true
-			globalMutex.Lock()
-			if globalBoard.State == 0 {
-				//log.Println(dd["player"])
-				player := dd["player"]
-				newPlayer := PlayerName{player.(string)}
-				globalBoard.clientIdToPlayerName[c.id] = newPlayer
-				pls := globalBoard.PlayerNames
-				if pls == nil {
-					pls = make([]PlayerName, 0)
-					globalBoard.PlayerNames = pls
-				}
-
-				pls = append(pls, newPlayer)
-				globalBoard.PlayerNames = pls
-			}
-			globalMutex.Unlock()
-		} else if tp == "start_game" {
-			isGameCommand = true
-			var sg StartGameMessage
-			json.Unmarshal(message, &sg)
-			StartGameHandler(sg.Content)
-		} else if tp == "murder" {
-			isGameCommand = true
-			var sg MurderMessage
-			json.Unmarshal(message, &sg)
-			HandleMurder(sg.Content)
-		} else if tp == "sir_pick" {
-			isGameCommand = true
-			var sg SirMessage
-			json.Unmarshal(message, &sg)
-			HandleSir(sg.Content)
-		} else if tp == "excalibur_pick" {
-			isGameCommand = true
-			var sg ExcaliburMessage
-			json.Unmarshal(message, &sg)
-			ExcaliburHandler(sg.Content)
-		} else if tp == "lady_suggest" {
-			isGameCommand = true
-			var sg LadySuggestMessage
-			json.Unmarshal(message, &sg)
-			LadySuggestHandler(sg.Content)
-		} else if tp == "lady_response" {
-			isGameCommand = true
-			var sg LadyResponseMessage
-			json.Unmarshal(message, &sg)
-			LadyResponseHandler(sg.Content)
-		} else if tp == "lady_publish_response" {
-			isGameCommand = true
-			var sg LadyPublishResponseMessage
-			json.Unmarshal(message, &sg)
-			LadyPublishResponseHandler(sg.Content)
-		} else if tp == "vote_for_suggestion" {
-			isGameCommand = true
-			var sg VoteForSuggestionMessage
-			json.Unmarshal(message, &sg)
-			log.Println("=====================>")
-			HandleSuggestionVote(sg.Content)
-			log.Println("<=====================")
-		} else if tp == "suggestion" {
-			isGameCommand = true
-			var sg SuggestMessage
-			json.Unmarshal(message, &sg)
-			HandleNewSuggest(sg.Content)
-		} else if tp == "suggestion_tmp" {
-			isOnlyForAllExceptSender = true
-			isGameCommand = true
-			var sg SuggestTmpMessage
-			json.Unmarshal(message, &sg)
-			HandleTemporarySuggest(sg.Content)
-		} else if tp == "vote_for_journey" {
-			isGameCommand = true
-			var sg VoteForJourneyMessage
-			json.Unmarshal(message, &sg)
-			HandleJourneyVote(sg.Content)
-		} else if tp == "refresh" || notifyAll {
-			if globalBoard.State == 0 {
-				isOnlyForSender = false
-			} else {
-				isOnlyForSender = true
-			}
-			isGameCommand = true
-		} else if tp == "reset" {
-			isGameCommand = true
-			globalMutex.Lock()
-			globalBoard = BoardGame{
-				QuestStage:               1,
-				lancelotCards:            make([]int, 7),
-				PlayersWithBadCharacter:  make([]string, 0),
-				playersWithGoodCharacter: make([]string, 0),
-				playersWithCharacters: 	make(map[string]string),
-				Secrets:                  make(map[string][]string),
-				SecretsMap: map[string]*PlayerSecrets{},
-				clientIdToPlayerName:     globalBoard.clientIdToPlayerName,
-				manager:                  globalBoard.manager,
-				PlayerToMurderInfo:       make(map[string]MurderInfo),
-				PlayerNames:              globalBoard.PlayerNames,
-				quests: QuestManager{
-					current:                    0,
-					playersVotes:               make([][]int, 20),
-					results:                    make(map[int]QuestStats),
-					realResults:                make(map[int]QuestStats),
-					successfulQuest:            0,
-					unsuccessfulQuest:          0,
-					playerVotedForCurrent:      make(map[string]int),
-					playerVotedForCurrentQuest: make([]string, 0),
-					differentResults:           make(map[int]int),
-					Flags:                      make(map[int]bool),
-				},
-			}
-
-			globalMutex.Unlock()
-		}
-		if isGameCommand == true {
-
-			if isOnlyForSender {
-				recipient = c.id
-			}
-			if isOnlyForAllExceptSender {
-				recipient = "^" + c.id
-			}
-			jsonMessage, _ := json.Marshal(&Message{Sender: c.id, Recipient: recipient, Content: "board"})
-			globalBoard.manager.broadcast <- jsonMessage
-		} else {
-			jsonMessage, _ := json.Marshal(&Message{Sender: c.id, Content: string(message)})
-			globalBoard.manager.broadcast <- jsonMessage
-		}
-
-		if notifyAll {
-			break
+		c.touch()
+		c.socket.SetReadDeadline(time.Now().Add(pongWait))
+		envelope, err := parseEnvelope(message)
+		if err == nil {
+			log.Println("successfully read message. client:", c.id, ". type: ", envelope.Type)
+			err = c.handleCommand(envelope)
 		}
+		c.reply(envelope, err)
 	}
 }
 
 func (manager *ClientManager) send(message []byte, ignore *Client) {
 	for conn := range manager.clients {
 		if conn != ignore {
-			conn.send <- message
+			manager.enqueue(conn, message, false)
 		}
 	}
 }
 
 type ClientManager struct {
+	board      *BoardGame
 	clients    map[*Client]bool
 	broadcast  chan []byte
 	register   chan *Client
 	unregister chan *Client
+	leave      chan *Client
+
+	seq    int64                         // sequence number of the last broadcast in this room
+	missed map[string][]sequencedMessage // broadcasts kept for seated players that are disconnected
+	stats  sendStats                     // slow consumer counters of this room
 }
 
 type Client struct {
-	id     string
-	socket *websocket.Conn
-	send   chan []byte
+	lastSeen int64 // unix nano of the last message or pong, accessed atomically
+
+	id      string
+	room    *BoardGame
+	socket  *websocket.Conn
+	send    *sendQueue
+	lastSeq int64 // last broadcast the client saw before reconnecting
+	patch   *patchState
 }